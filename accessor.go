@@ -0,0 +1,31 @@
+package gotrans
+
+// TranslationFieldGetter is an optional interface that lets SaveTranslations
+// read translatable fields without reflection. It is detected on *T, so a
+// value receiver works as well.
+type TranslationFieldGetter interface {
+	// TranslationFieldValue returns the value of the field mapped to the DB
+	// field ID and whether such a field exists.
+	TranslationFieldValue(field string) (string, bool)
+}
+
+// TranslationFieldSetter is an optional interface that lets LoadTranslations
+// write translatable fields without reflection. It is detected on *T, so the
+// setter is expected to have a pointer receiver.
+type TranslationFieldSetter interface {
+	// SetTranslationFieldValue assigns value to the field mapped to the DB
+	// field ID and reports whether such a field exists.
+	SetTranslationFieldValue(field, value string) bool
+}
+
+// TranslationAccessor combines both optional interfaces. Implementations are
+// normally produced by cmd/gotrans-gen from struct tags:
+//
+//	//go:generate gotrans-gen -type Product -entity product
+//
+// Each half is used independently: an entity may implement only the getter or
+// only the setter, and the other direction keeps using reflection.
+type TranslationAccessor interface {
+	TranslationFieldGetter
+	TranslationFieldSetter
+}
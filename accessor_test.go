@@ -0,0 +1,259 @@
+package gotrans
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+//go:generate go run ./cmd/gotrans-gen -type Article -entity article -output article_gotrans_test.go
+
+// Article uses gotrans-gen output (article_gotrans_test.go) instead of
+// hand-written TranslatableFields, so the translator takes the accessor path.
+type Article struct {
+	ID     int
+	locale Locale
+	Title  string `gotrans:"title"`
+	Body   string `gotrans:"body"`
+	Slug   string
+}
+
+var _ TranslationAccessor = (*Article)(nil)
+
+func (a Article) TranslationEntityID() int        { return a.ID }
+func (a Article) TranslationEntityLocale() Locale { return a.locale }
+
+func TestAccessor_LoadTranslations(t *testing.T) {
+	repo := &mockRepo{
+		translations: []Translation{
+			{ID: 1, Entity: "article", EntityID: 1, Field: "title", Locale: LocaleEN, Value: "Title EN"},
+			{ID: 2, Entity: "article", EntityID: 1, Field: "body", Locale: LocaleEN, Value: "Body EN"},
+			{ID: 3, Entity: "article", EntityID: 1, Field: "slug", Locale: LocaleEN, Value: "ignored"},
+		},
+	}
+	trans := NewTranslator[Article](repo)
+
+	articles, err := trans.LoadTranslations(context.Background(), []Article{{ID: 1, locale: LocaleEN}})
+	require.NoError(t, err)
+	require.Equal(t, "Title EN", articles[0].Title)
+	require.Equal(t, "Body EN", articles[0].Body)
+	require.Empty(t, articles[0].Slug, "untagged fields must not be set")
+}
+
+func TestAccessor_SaveTranslations(t *testing.T) {
+	repo := &mockRepo{}
+	trans := NewTranslator[Article](repo)
+
+	err := trans.SaveTranslations(context.Background(), []Article{
+		{ID: 1, locale: LocaleFR, Title: "Titre", Body: "Corps", Slug: "titre"},
+	})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []Translation{
+		{Entity: "article", EntityID: 1, Field: "body", Locale: LocaleFR, Value: "Corps"},
+		{Entity: "article", EntityID: 1, Field: "title", Locale: LocaleFR, Value: "Titre"},
+	}, repo.saved)
+}
+
+func TestAccessor_TypedMethods(t *testing.T) {
+	a := Article{Title: "Old"}
+	a.SetTranslatedTitle("New")
+	require.Equal(t, "New", a.TranslatedTitle())
+	require.Equal(t, "article", a.TranslationEntityName())
+	require.Equal(t, map[string]string{"Title": "title", "Body": "body"}, a.TranslatableFields())
+}
+
+// Headline implements only TranslationFieldSetter by hand; extraction keeps
+// using reflection.
+type Headline struct {
+	ID     int
+	locale Locale
+	Text   string
+	sets   int
+}
+
+func (h Headline) TranslationEntityID() int              { return h.ID }
+func (h Headline) TranslationEntityLocale() Locale       { return h.locale }
+func (h Headline) TranslationEntityName() string         { return "headline" }
+func (h Headline) TranslatableFields() map[string]string { return map[string]string{"Text": "text"} }
+
+func (h *Headline) SetTranslationFieldValue(field, value string) bool {
+	if field != "text" {
+		return false
+	}
+	h.Text = value
+	h.sets++
+	return true
+}
+
+// Caption implements only TranslationFieldGetter by hand; loading keeps using
+// reflection.
+type Caption struct {
+	ID     int
+	locale Locale
+	Text   string
+}
+
+func (c Caption) TranslationEntityID() int              { return c.ID }
+func (c Caption) TranslationEntityLocale() Locale       { return c.locale }
+func (c Caption) TranslationEntityName() string         { return "caption" }
+func (c Caption) TranslatableFields() map[string]string { return map[string]string{"Text": "text"} }
+
+func (c Caption) TranslationFieldValue(field string) (string, bool) {
+	if field != "text" {
+		return "", false
+	}
+	return strings.TrimSpace(c.Text), true
+}
+
+func TestAccessor_SetterOnly(t *testing.T) {
+	repo := &mockRepo{
+		translations: []Translation{
+			{Entity: "headline", EntityID: 1, Field: "text", Locale: LocaleEN, Value: "Hello"},
+		},
+	}
+	trans := NewTranslator[Headline](repo)
+	ctx := context.Background()
+
+	loaded, err := trans.LoadTranslations(ctx, []Headline{{ID: 1, locale: LocaleEN}})
+	require.NoError(t, err)
+	require.Equal(t, "Hello", loaded[0].Text)
+	require.Equal(t, 1, loaded[0].sets, "setter must be used instead of reflection")
+
+	require.NoError(t, trans.SaveTranslations(ctx, []Headline{{ID: 2, locale: LocaleEN, Text: "Bye"}}))
+	require.Equal(t, []Translation{
+		{Entity: "headline", EntityID: 2, Field: "text", Locale: LocaleEN, Value: "Bye"},
+	}, repo.saved)
+}
+
+func TestAccessor_GetterOnly(t *testing.T) {
+	repo := &mockRepo{
+		translations: []Translation{
+			{Entity: "caption", EntityID: 1, Field: "text", Locale: LocaleEN, Value: " Loaded "},
+		},
+	}
+	trans := NewTranslator[Caption](repo)
+	ctx := context.Background()
+
+	require.NoError(t, trans.SaveTranslations(ctx, []Caption{{ID: 2, locale: LocaleEN, Text: "  Saved  "}}))
+	require.Equal(t, []Translation{
+		{Entity: "caption", EntityID: 2, Field: "text", Locale: LocaleEN, Value: "Saved"},
+	}, repo.saved, "getter must be used instead of reflection")
+
+	loaded, err := trans.LoadTranslations(ctx, []Caption{{ID: 1, locale: LocaleEN}})
+	require.NoError(t, err)
+	require.Equal(t, " Loaded ", loaded[0].Text, "reflection sets the raw value")
+}
+
+// ReflectArticle is Article without generated methods, for benchmark comparison.
+type ReflectArticle struct {
+	ID     int
+	locale Locale
+	Title  string
+	Body   string
+}
+
+func (a ReflectArticle) TranslationEntityID() int        { return a.ID }
+func (a ReflectArticle) TranslationEntityLocale() Locale { return a.locale }
+func (a ReflectArticle) TranslationEntityName() string   { return "reflect_article" }
+func (a ReflectArticle) TranslatableFields() map[string]string {
+	return map[string]string{"Title": "title", "Body": "body"}
+}
+
+func benchmarkArticleRepo(entity string, n int) *mockRepo {
+	repo := &mockRepo{}
+	for i := 1; i <= n; i++ {
+		repo.translations = append(repo.translations,
+			Translation{Entity: entity, EntityID: i, Field: "title", Locale: LocaleEN, Value: "Title"},
+			Translation{Entity: entity, EntityID: i, Field: "body", Locale: LocaleEN, Value: "Body"},
+		)
+	}
+	return repo
+}
+
+const benchArticles = 100
+
+func BenchmarkLoadTranslations_Reflection(b *testing.B) {
+	trans := NewTranslator[ReflectArticle](benchmarkArticleRepo("reflect_article", benchArticles))
+	entities := make([]ReflectArticle, benchArticles)
+	for i := range entities {
+		entities[i] = ReflectArticle{ID: i + 1, locale: LocaleEN}
+	}
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = trans.LoadTranslations(ctx, entities)
+	}
+}
+
+func BenchmarkLoadTranslations_Generated(b *testing.B) {
+	trans := NewTranslator[Article](benchmarkArticleRepo("article", benchArticles))
+	entities := make([]Article, benchArticles)
+	for i := range entities {
+		entities[i] = Article{ID: i + 1, locale: LocaleEN}
+	}
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = trans.LoadTranslations(ctx, entities)
+	}
+}
+
+func BenchmarkSaveTranslations_Reflection(b *testing.B) {
+	trans := NewTranslator[ReflectArticle](&discardRepo{})
+	entities := make([]ReflectArticle, benchArticles)
+	for i := range entities {
+		entities[i] = ReflectArticle{ID: i + 1, locale: LocaleEN, Title: "Title", Body: "Body"}
+	}
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = trans.SaveTranslations(ctx, entities)
+	}
+}
+
+func BenchmarkSaveTranslations_Generated(b *testing.B) {
+	trans := NewTranslator[Article](&discardRepo{})
+	entities := make([]Article, benchArticles)
+	for i := range entities {
+		entities[i] = Article{ID: i + 1, locale: LocaleEN, Title: "Title", Body: "Body"}
+	}
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = trans.SaveTranslations(ctx, entities)
+	}
+}
+
+// discardRepo accepts writes without storing them, so save benchmarks measure
+// extraction rather than the mock's bookkeeping.
+type discardRepo struct{ mockRepo }
+
+func (*discardRepo) MassCreateOrUpdate(context.Context, Locale, []Translation) error { return nil }
+
+func TestAccessor_PointerEntities(t *testing.T) {
+	repo := &mockRepo{
+		translations: []Translation{
+			{Entity: "article", EntityID: 1, Field: "title", Locale: LocaleEN, Value: "Title EN"},
+		},
+	}
+	trans := NewTranslator[*Article](repo)
+	ctx := context.Background()
+
+	a := &Article{ID: 1, locale: LocaleEN}
+	_, err := trans.LoadTranslations(ctx, []*Article{nil, a})
+	require.NoError(t, err)
+	require.Equal(t, "Title EN", a.Title)
+
+	require.NoError(t, trans.SaveTranslations(ctx, []*Article{{ID: 2, locale: LocaleEN, Title: "T", Body: "B"}, nil}))
+	require.Len(t, repo.saved, 2)
+}
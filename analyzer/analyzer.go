@@ -0,0 +1,410 @@
+// Package analyzer reports mistakes in gotrans.Translatable implementations
+// that would otherwise only show up at runtime as empty fields.
+//
+// For every type implementing gotrans.Translatable it checks that:
+//   - each TranslatableFields key names a direct, exported struct field of a
+//     type the translator supports (string, *string, []string,
+//     gotrans.PluralText or a sql.NullString-style wrapper);
+//   - no two struct fields map to the same DB field ID, and no ID contains the
+//     '#' reserved for plural variants;
+//   - TranslationEntityName does not return an empty string;
+//   - no two types in the same module use the same entity name.
+//
+// Only constant map literals (returned directly or through a package-level
+// variable, as gotrans-gen does) and constant entity names are inspected;
+// computed values are skipped rather than guessed at.
+//
+// Entity names are passed between packages as analysis facts, so a package
+// is compared with the same-module packages it imports, directly or
+// transitively. go vet analyzes one package at a time and cannot compare two
+// sibling packages that never import each other; CheckModule does, and
+// gotrans-vet runs it when invoked on its own.
+//
+// Run it standalone for the module-wide check:
+//
+//	go install github.com/ivan-gorbushko/gotrans/cmd/gotrans-vet@latest
+//	gotrans-vet ./...
+//
+// or with go vet:
+//
+//	go vet -vettool=$(which gotrans-vet) ./...
+package analyzer
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"reflect"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// gotransPath is the import path that declares the Translatable interface.
+const gotransPath = "github.com/ivan-gorbushko/gotrans"
+
+// Analyzer checks gotrans.Translatable implementations.
+var Analyzer = &analysis.Analyzer{
+	Name:       "gotrans",
+	Doc:        "check gotrans.Translatable implementations for invalid field mappings and entity names",
+	URL:        "https://pkg.go.dev/github.com/ivan-gorbushko/gotrans/analyzer",
+	Run:        run,
+	FactTypes:  []analysis.Fact{new(entityNamesFact)},
+	ResultType: reflect.TypeOf((*declaredEntities)(nil)),
+}
+
+// declaredEntities is the Analyzer's result: the entity names a package
+// declares, for CheckModule.
+type declaredEntities struct {
+	names     map[string]string    // entity name → qualified type name
+	positions map[string]token.Pos // entity name → declaring type
+}
+
+// entityNamesFact records the entity names declared by a package so that
+// importing packages can detect collisions. Keyed by entity name, valued by
+// the qualified type name that declares it.
+type entityNamesFact struct {
+	Names map[string]string
+}
+
+func (*entityNamesFact) AFact() {}
+
+func (f *entityNamesFact) String() string {
+	names := make([]string, 0, len(f.Names))
+	for n := range f.Names {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return "entityNames(" + strings.Join(names, ", ") + ")"
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	iface := lookupTranslatable(pass.Pkg)
+	if iface == nil {
+		return (*declaredEntities)(nil), nil // package does not import gotrans
+	}
+
+	decls := collectMethodDecls(pass.Files)
+	vars := collectVarInits(pass)
+
+	declared := make(map[string]string)     // entity name → qualified type name
+	positions := make(map[string]token.Pos) // entity name → first declaring type
+
+	for _, tn := range typeNamesInSourceOrder(pass.Pkg) {
+		name := tn.Name()
+		named, ok := tn.Type().(*types.Named)
+		if !ok || types.IsInterface(named) {
+			continue
+		}
+		if !types.Implements(named, iface) && !types.Implements(types.NewPointer(named), iface) {
+			continue
+		}
+
+		methods := decls[name]
+		checkFields(pass, named, methods["TranslatableFields"], vars)
+
+		entity, ok := entityName(pass, methods["TranslationEntityName"])
+		if !ok {
+			continue
+		}
+		qualified := pass.Pkg.Path() + "." + name
+		if prev, dup := declared[entity]; dup {
+			pass.Reportf(tn.Pos(), "entity name %q of %s is already used by %s", entity, name, prev)
+			continue
+		}
+		declared[entity] = qualified
+		positions[entity] = tn.Pos()
+	}
+
+	// Compare against entity names exported by imported packages of the same module.
+	for _, pf := range pass.AllPackageFacts() {
+		fact, ok := pf.Fact.(*entityNamesFact)
+		if !ok || pf.Package == pass.Pkg || !sameModule(pass, pf.Package.Path()) {
+			continue
+		}
+		for entity, other := range fact.Names {
+			if _, clash := declared[entity]; clash {
+				pass.Reportf(positions[entity], "entity name %q of %s is already used by %s",
+					entity, strings.TrimPrefix(declared[entity], pass.Pkg.Path()+"."), other)
+			}
+		}
+	}
+
+	if len(declared) > 0 {
+		pass.ExportPackageFact(&entityNamesFact{Names: declared})
+	}
+	return &declaredEntities{names: declared, positions: positions}, nil
+}
+
+// typeNamesInSourceOrder returns the package-level type names ordered by
+// position, so that collisions are reported on the later declaration.
+func typeNamesInSourceOrder(pkg *types.Package) []*types.TypeName {
+	scope := pkg.Scope()
+	var tns []*types.TypeName
+	for _, name := range scope.Names() {
+		if tn, ok := scope.Lookup(name).(*types.TypeName); ok && !tn.IsAlias() {
+			tns = append(tns, tn)
+		}
+	}
+	sort.Slice(tns, func(i, j int) bool { return tns[i].Pos() < tns[j].Pos() })
+	return tns
+}
+
+// lookupTranslatable finds gotrans.Translatable in pkg itself or in its direct
+// imports. Packages that don't import gotrans are not checked.
+func lookupTranslatable(pkg *types.Package) *types.Interface {
+	candidates := append([]*types.Package{pkg}, pkg.Imports()...)
+	for _, p := range candidates {
+		if p.Path() != gotransPath {
+			continue
+		}
+		obj, ok := p.Scope().Lookup("Translatable").(*types.TypeName)
+		if !ok {
+			return nil
+		}
+		iface, _ := obj.Type().Underlying().(*types.Interface)
+		return iface
+	}
+	return nil
+}
+
+// sameModule reports whether path belongs to the module being analyzed.
+// Without module information every package is considered part of it.
+func sameModule(pass *analysis.Pass, path string) bool {
+	if pass.Module == nil || pass.Module.Path == "" {
+		return true
+	}
+	mod := pass.Module.Path
+	return path == mod || strings.HasPrefix(path, mod+"/")
+}
+
+// collectMethodDecls indexes method declarations by receiver type name and method name.
+func collectMethodDecls(files []*ast.File) map[string]map[string]*ast.FuncDecl {
+	decls := make(map[string]map[string]*ast.FuncDecl)
+	for _, f := range files {
+		for _, d := range f.Decls {
+			fd, ok := d.(*ast.FuncDecl)
+			if !ok || fd.Recv == nil || len(fd.Recv.List) == 0 || fd.Body == nil {
+				continue
+			}
+			recv := receiverName(fd.Recv.List[0].Type)
+			if recv == "" {
+				continue
+			}
+			if decls[recv] == nil {
+				decls[recv] = make(map[string]*ast.FuncDecl)
+			}
+			decls[recv][fd.Name.Name] = fd
+		}
+	}
+	return decls
+}
+
+func receiverName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(e.X)
+	case *ast.Ident:
+		return e.Name
+	case *ast.IndexExpr:
+		return receiverName(e.X)
+	case *ast.IndexListExpr:
+		return receiverName(e.X)
+	}
+	return ""
+}
+
+// collectVarInits maps package-level variables to their initializer expressions,
+// so that `return productFields` can be followed to its map literal.
+func collectVarInits(pass *analysis.Pass) map[types.Object]ast.Expr {
+	inits := make(map[types.Object]ast.Expr)
+	for _, f := range pass.Files {
+		for _, d := range f.Decls {
+			gd, ok := d.(*ast.GenDecl)
+			if !ok || gd.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				vs := spec.(*ast.ValueSpec)
+				if len(vs.Names) != len(vs.Values) {
+					continue
+				}
+				for i, n := range vs.Names {
+					if obj := pass.TypesInfo.Defs[n]; obj != nil {
+						inits[obj] = vs.Values[i]
+					}
+				}
+			}
+		}
+	}
+	return inits
+}
+
+// returnedExprs returns the single-value results of fd's return statements,
+// ignoring those of nested function literals.
+func returnedExprs(fd *ast.FuncDecl) []ast.Expr {
+	var exprs []ast.Expr
+	ast.Inspect(fd.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if len(n.Results) == 1 {
+				exprs = append(exprs, n.Results[0])
+			}
+		}
+		return true
+	})
+	return exprs
+}
+
+// checkFields validates the map literals returned by TranslatableFields.
+func checkFields(pass *analysis.Pass, named *types.Named, fd *ast.FuncDecl, vars map[types.Object]ast.Expr) {
+	if fd == nil {
+		return
+	}
+	st, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return
+	}
+	fields := make(map[string]*types.Var, st.NumFields())
+	for i := 0; i < st.NumFields(); i++ {
+		fields[st.Field(i).Name()] = st.Field(i)
+	}
+
+	for _, expr := range returnedExprs(fd) {
+		lit := mapLiteral(pass, expr, vars)
+		if lit == nil {
+			continue
+		}
+		byID := make(map[string]string) // DB field ID → struct field name
+		for _, elt := range lit.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			name, okName := stringConst(pass, kv.Key)
+			id, okID := stringConst(pass, kv.Value)
+			if okName {
+				checkField(pass, named, kv.Key, name, fields)
+			}
+			if !okName || !okID {
+				continue
+			}
+			if id == "" {
+				pass.Reportf(kv.Value.Pos(), "%s.%s maps to an empty DB field ID", named.Obj().Name(), name)
+				continue
+			}
+			if strings.Contains(id, "#") {
+				pass.Reportf(kv.Value.Pos(), "%s.%s maps to DB field ID %q; '#' is reserved for plural variants", named.Obj().Name(), name, id)
+			}
+			if prev, dup := byID[id]; dup {
+				pass.Reportf(kv.Value.Pos(), "%s: fields %s and %s both map to DB field ID %q",
+					named.Obj().Name(), prev, name, id)
+				continue
+			}
+			byID[id] = name
+		}
+	}
+}
+
+func checkField(pass *analysis.Pass, named *types.Named, key ast.Expr, name string, fields map[string]*types.Var) {
+	typ := named.Obj().Name()
+	f, ok := fields[name]
+	switch {
+	case !ok:
+		pass.Reportf(key.Pos(), "%s has no field %s", typ, name)
+	case f.Embedded():
+		pass.Reportf(key.Pos(), "%s.%s is an embedded field and cannot hold a translation", typ, name)
+	case !f.Exported():
+		pass.Reportf(key.Pos(), "%s.%s is unexported and cannot be set by the translator", typ, name)
+	case !isTranslatableType(f.Type()):
+		pass.Reportf(key.Pos(), "%s.%s has type %s; translatable fields must be string, *string, []string, gotrans.PluralText or a sql.NullString-style wrapper",
+			typ, name, types.TypeString(f.Type(), types.RelativeTo(pass.Pkg)))
+	}
+}
+
+// isTranslatableType reports whether the translator can read and write t:
+// strings, *string, []string, gotrans.PluralText and sql.NullString-style
+// wrappers (a type with a Value method whose pointer has a Scan method).
+func isTranslatableType(t types.Type) bool {
+	if isString(t) || isNullable(t) || isPluralText(t) {
+		return true
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Pointer:
+		return isString(u.Elem())
+	case *types.Slice:
+		return isString(u.Elem())
+	}
+	return false
+}
+
+func isString(t types.Type) bool {
+	b, ok := t.Underlying().(*types.Basic)
+	return ok && b.Kind() == types.String
+}
+
+func isPluralText(t types.Type) bool {
+	n, ok := t.(*types.Named)
+	return ok && n.Obj().Name() == "PluralText" && n.Obj().Pkg() != nil && n.Obj().Pkg().Path() == gotransPath
+}
+
+func isNullable(t types.Type) bool {
+	hasMethod := func(t types.Type, name string) bool {
+		return types.NewMethodSet(t).Lookup(nil, name) != nil
+	}
+	return hasMethod(t, "Value") && hasMethod(types.NewPointer(t), "Scan")
+}
+
+// mapLiteral resolves expr to a map composite literal, following a reference
+// to a package-level variable once.
+func mapLiteral(pass *analysis.Pass, expr ast.Expr, vars map[types.Object]ast.Expr) *ast.CompositeLit {
+	expr = ast.Unparen(expr)
+	if id, ok := expr.(*ast.Ident); ok {
+		init, ok := vars[pass.TypesInfo.Uses[id]]
+		if !ok {
+			return nil
+		}
+		expr = ast.Unparen(init)
+	}
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return nil
+	}
+	if _, ok := pass.TypesInfo.TypeOf(lit).Underlying().(*types.Map); !ok {
+		return nil
+	}
+	return lit
+}
+
+// entityName returns the constant entity name returned by TranslationEntityName.
+// An empty constant is reported; non-constant results are skipped.
+func entityName(pass *analysis.Pass, fd *ast.FuncDecl) (string, bool) {
+	if fd == nil {
+		return "", false
+	}
+	results := returnedExprs(fd)
+	if len(results) != 1 {
+		return "", false
+	}
+	name, ok := stringConst(pass, results[0])
+	if !ok {
+		return "", false
+	}
+	if name == "" {
+		pass.Reportf(results[0].Pos(), "TranslationEntityName returns an empty string")
+		return "", false
+	}
+	return name, true
+}
+
+func stringConst(pass *analysis.Pass, expr ast.Expr) (string, bool) {
+	tv, ok := pass.TypesInfo.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(tv.Value), true
+}
@@ -0,0 +1,42 @@
+package analyzer_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ivan-gorbushko/gotrans/analyzer"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), analyzer.Analyzer, "catalog", "shop")
+}
+
+func TestCheckModule(t *testing.T) {
+	gopath, err := filepath.Abs(analysistest.TestData())
+	require.NoError(t, err)
+	cfg := &packages.Config{
+		Mode: packages.LoadAllSyntax,
+		Dir:  filepath.Join(gopath, "src"),
+		Env:  append(os.Environ(), "GOPATH="+gopath, "GO111MODULE=off", "GOWORK=off", "GOFLAGS="),
+	}
+	pkgs, err := packages.Load(cfg, "siblings/...", "catalog", "shop")
+	require.NoError(t, err)
+	require.Zero(t, packages.PrintErrors(pkgs))
+	graph, err := checker.Analyze([]*analysis.Analyzer{analyzer.Analyzer}, pkgs, nil)
+	require.NoError(t, err)
+
+	collisions := analyzer.CheckModule(graph)
+	require.Len(t, collisions, 1, "page imports banner and shop imports catalog; only promo is a sibling")
+	c := collisions[0]
+	require.Equal(t, "banner", c.Entity)
+	require.Equal(t, "siblings/promo.Promo", c.Type)
+	require.Equal(t, "siblings/banner.Banner", c.Other)
+	require.Equal(t, "promo.go", filepath.Base(c.Pos.Filename))
+	require.Equal(t, 6, c.Pos.Line)
+}
@@ -0,0 +1,118 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/token"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+// Collision is an entity name declared by types in two packages of the same
+// module that don't import each other.
+type Collision struct {
+	Pos    token.Position // declaration of Type
+	Entity string
+	Type   string // qualified name of the type reported
+	Other  string // qualified name of the type declared first
+}
+
+func (c Collision) String() string {
+	return fmt.Sprintf("%s: entity name %q of %s is already used by %s", c.Pos, c.Entity, c.Type, c.Other)
+}
+
+// CheckModule compares the entity names declared by the root packages of
+// graph, which must include Analyzer, across packages that don't import each
+// other. Collisions along imports are reported by Analyzer itself. Packages
+// are visited in import path order and each collision is reported on the
+// later one. When test variants are loaded, only the variant that includes
+// the test files is compared. Commands (package main) are left out: they are
+// separate programs, each already compared with what it imports.
+func CheckModule(graph *checker.Graph) []Collision {
+	type root struct {
+		pkg      *packages.Package
+		entities *declaredEntities
+	}
+	byPath := make(map[string]root)
+	for _, act := range graph.Roots {
+		entities, ok := act.Result.(*declaredEntities)
+		if act.Analyzer != Analyzer || !ok || entities == nil {
+			continue
+		}
+		pkg := act.Package
+		if pkg.Name == "main" {
+			continue
+		}
+		if prev, dup := byPath[pkg.PkgPath]; dup && len(prev.pkg.Syntax) >= len(pkg.Syntax) {
+			continue
+		}
+		byPath[pkg.PkgPath] = root{pkg, entities}
+	}
+	paths := make([]string, 0, len(byPath))
+	for path := range byPath {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	type first struct {
+		pkg  *packages.Package
+		name string
+	}
+	var collisions []Collision
+	seen := make(map[string][]first) // module + entity name → declarations so far
+	for _, path := range paths {
+		r := byPath[path]
+		names := make([]string, 0, len(r.entities.names))
+		for entity := range r.entities.names {
+			names = append(names, entity)
+		}
+		sort.Strings(names)
+		for _, entity := range names {
+			key := moduleOf(r.pkg) + "\x00" + entity
+			for _, prev := range seen[key] {
+				if imports(r.pkg, prev.pkg) || imports(prev.pkg, r.pkg) {
+					continue
+				}
+				collisions = append(collisions, Collision{
+					Pos:    r.pkg.Fset.Position(r.entities.positions[entity]),
+					Entity: entity,
+					Type:   r.entities.names[entity],
+					Other:  prev.name,
+				})
+				break
+			}
+			seen[key] = append(seen[key], first{r.pkg, r.entities.names[entity]})
+		}
+	}
+	return collisions
+}
+
+// moduleOf returns the module path of pkg, "" without module information.
+func moduleOf(pkg *packages.Package) string {
+	if pkg.Module == nil {
+		return ""
+	}
+	return pkg.Module.Path
+}
+
+// imports reports whether pkg imports dep, directly or transitively. Test
+// variants count as the package they test.
+func imports(pkg, dep *packages.Package) bool {
+	visited := make(map[*packages.Package]bool)
+	var walk func(p *packages.Package) bool
+	walk = func(p *packages.Package) bool {
+		if visited[p] {
+			return false
+		}
+		visited[p] = true
+		for _, imp := range p.Imports {
+			if imp.PkgPath == dep.PkgPath || walk(imp) {
+				return true
+			}
+		}
+		return false
+	}
+	return walk(pkg) || strings.TrimSuffix(pkg.PkgPath, "_test") == dep.PkgPath
+}
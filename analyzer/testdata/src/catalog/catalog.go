@@ -0,0 +1,94 @@
+package catalog // want package:`entityNames\(category, product\)`
+
+import "github.com/ivan-gorbushko/gotrans"
+
+type Base struct{ Note string }
+
+type Product struct {
+	Base
+	ID          int
+	locale      gotrans.Locale
+	Title       string
+	Description string
+	Price       int
+	secret      string
+	Subtitle2   *string
+	Bullets     []string
+	Note        NullText
+	Counts      []int
+	Stock       gotrans.PluralText
+}
+
+// NullText is a sql.NullString-style wrapper.
+type NullText struct {
+	String string
+	Valid  bool
+}
+
+func (n NullText) Value() (any, error) { return n.String, nil }
+func (n *NullText) Scan(src any) error { return nil }
+
+func (p Product) TranslationEntityID() int                { return p.ID }
+func (p Product) TranslationEntityLocale() gotrans.Locale { return p.locale }
+func (p Product) TranslationEntityName() string           { return "product" }
+func (p Product) TranslatableFields() map[string]string {
+	return map[string]string{
+		"Title":       "title",
+		"Description": "title",    // want `Product: fields Title and Description both map to DB field ID "title"`
+		"Subtitle":    "subtitle", // want `Product has no field Subtitle`
+		"Price":       "price",    // want `Product.Price has type int; translatable fields must be string, \*string, \[\]string, gotrans.PluralText or a sql.NullString-style wrapper`
+		"Subtitle2":   "subtitle2",
+		"Bullets":     "bullets",
+		"Note":        "note",
+		"Stock":       "stock",
+		"Stock2":      "stock#one", // want `Product has no field Stock2` `Product.Stock2 maps to DB field ID "stock#one"; '#' is reserved for plural variants`
+		"Counts":      "counts",    // want `Product.Counts has type \[\]int`
+		"secret":      "secret",    // want `Product.secret is unexported and cannot be set by the translator`
+		"Base":        "base",      // want `Product.Base is an embedded field and cannot hold a translation`
+	}
+}
+
+type Name string
+
+// Category returns its mapping through a package-level variable, as gotrans-gen does.
+type Category struct {
+	ID    int
+	Title Name
+	Body  string
+}
+
+var categoryFields = map[string]string{
+	"Title": "title",
+	"Body":  "",     // want `Category.Body maps to an empty DB field ID`
+	"Slug":  "slug", // want `Category has no field Slug`
+}
+
+func (c Category) TranslationEntityID() int                { return c.ID }
+func (c Category) TranslationEntityLocale() gotrans.Locale { return 0 }
+func (c Category) TranslationEntityName() string           { return "category" }
+func (c Category) TranslatableFields() map[string]string   { return categoryFields }
+
+type Tag struct{ ID int }
+
+func (t *Tag) TranslationEntityID() int                { return t.ID }
+func (t *Tag) TranslationEntityLocale() gotrans.Locale { return 0 }
+func (t *Tag) TranslationEntityName() string           { return "" } // want `TranslationEntityName returns an empty string`
+func (t *Tag) TranslatableFields() map[string]string   { return nil }
+
+type Duplicate struct{ ID int } // want `entity name "product" of Duplicate is already used by catalog.Product`
+
+func (d Duplicate) TranslationEntityID() int                { return d.ID }
+func (d Duplicate) TranslationEntityLocale() gotrans.Locale { return 0 }
+func (d Duplicate) TranslationEntityName() string           { return "product" }
+func (d Duplicate) TranslatableFields() map[string]string   { return map[string]string{} }
+
+// Dynamic mappings are not inspected.
+type Dynamic struct{ ID int }
+
+func (d Dynamic) TranslationEntityID() int                { return d.ID }
+func (d Dynamic) TranslationEntityLocale() gotrans.Locale { return 0 }
+func (d Dynamic) TranslationEntityName() string           { return entityPrefix() + "dynamic" }
+func (d Dynamic) TranslatableFields() map[string]string   { return buildFields() }
+
+func entityPrefix() string           { return "" }
+func buildFields() map[string]string { return map[string]string{"Missing": "missing"} }
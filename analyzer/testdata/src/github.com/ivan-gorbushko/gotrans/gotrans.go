@@ -0,0 +1,14 @@
+package gotrans
+
+type Locale int16
+
+type Translatable interface {
+	TranslationEntityID() int
+	TranslationEntityName() string
+	TranslationEntityLocale() Locale
+	TranslatableFields() map[string]string
+}
+
+type PluralCategory string
+
+type PluralText map[PluralCategory]string
@@ -0,0 +1,19 @@
+package shop // want package:`entityNames\(category\)`
+
+import (
+	_ "catalog"
+
+	"github.com/ivan-gorbushko/gotrans"
+)
+
+type Offer struct { // want `entity name "category" of Offer is already used by catalog.Category`
+	ID    int
+	Title string
+}
+
+func (o Offer) TranslationEntityID() int                { return o.ID }
+func (o Offer) TranslationEntityLocale() gotrans.Locale { return 0 }
+func (o Offer) TranslationEntityName() string           { return "category" }
+func (o Offer) TranslatableFields() map[string]string {
+	return map[string]string{"Title": "title"}
+}
@@ -0,0 +1,15 @@
+package banner
+
+import "github.com/ivan-gorbushko/gotrans"
+
+type Banner struct {
+	ID    int
+	Title string
+}
+
+func (b Banner) TranslationEntityID() int                { return b.ID }
+func (b Banner) TranslationEntityLocale() gotrans.Locale { return 0 }
+func (b Banner) TranslationEntityName() string           { return "banner" }
+func (b Banner) TranslatableFields() map[string]string {
+	return map[string]string{"Title": "title"}
+}
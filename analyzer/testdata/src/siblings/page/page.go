@@ -0,0 +1,21 @@
+package page
+
+import (
+	_ "siblings/banner"
+
+	"github.com/ivan-gorbushko/gotrans"
+)
+
+// Page collides with banner.Banner along an import, which the analyzer
+// reports itself.
+type Page struct {
+	ID    int
+	Title string
+}
+
+func (p Page) TranslationEntityID() int                { return p.ID }
+func (p Page) TranslationEntityLocale() gotrans.Locale { return 0 }
+func (p Page) TranslationEntityName() string           { return "banner" }
+func (p Page) TranslatableFields() map[string]string {
+	return map[string]string{"Title": "title"}
+}
@@ -0,0 +1,16 @@
+package promo
+
+import "github.com/ivan-gorbushko/gotrans"
+
+// Promo reuses the entity name of banner.Banner without importing it.
+type Promo struct {
+	ID    int
+	Title string
+}
+
+func (p Promo) TranslationEntityID() int                { return p.ID }
+func (p Promo) TranslationEntityLocale() gotrans.Locale { return 0 }
+func (p Promo) TranslationEntityName() string           { return "banner" }
+func (p Promo) TranslatableFields() map[string]string {
+	return map[string]string{"Title": "title"}
+}
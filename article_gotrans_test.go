@@ -0,0 +1,51 @@
+// Code generated by gotrans-gen. DO NOT EDIT.
+
+package gotrans
+
+// articleTranslatableFields is shared by every call to TranslatableFields; callers must not modify it.
+var articleTranslatableFields = map[string]string{
+	"Title": "title",
+	"Body":  "body",
+}
+
+// TranslationEntityName returns the name stored in the translations table.
+func (Article) TranslationEntityName() string { return "article" }
+
+// TranslatableFields returns the struct field name → DB field ID mapping.
+func (Article) TranslatableFields() map[string]string { return articleTranslatableFields }
+
+// TranslationFieldValue returns the value of the field mapped to the DB field ID.
+func (a Article) TranslationFieldValue(field string) (string, bool) {
+	switch field {
+	case "title":
+		return a.Title, true
+	case "body":
+		return a.Body, true
+	}
+	return "", false
+}
+
+// SetTranslationFieldValue sets the field mapped to the DB field ID.
+func (a *Article) SetTranslationFieldValue(field, value string) bool {
+	switch field {
+	case "title":
+		a.Title = value
+		return true
+	case "body":
+		a.Body = value
+		return true
+	}
+	return false
+}
+
+// TranslatedTitle returns the "title" translation.
+func (a Article) TranslatedTitle() string { return a.Title }
+
+// SetTranslatedTitle sets the "title" translation.
+func (a *Article) SetTranslatedTitle(value string) { a.Title = value }
+
+// TranslatedBody returns the "body" translation.
+func (a Article) TranslatedBody() string { return a.Body }
+
+// SetTranslatedBody sets the "body" translation.
+func (a *Article) SetTranslatedBody(value string) { a.Body = value }
@@ -0,0 +1,199 @@
+package gotrans
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+// AuditAction is the kind of write an AuditEntry records.
+type AuditAction string
+
+const (
+	AuditSave   AuditAction = "save"
+	AuditDelete AuditAction = "delete"
+)
+
+// AuditEntry records one write made through NewAuditRepository.
+//
+// A save lists the Keys it wrote. A delete records its scope instead, as
+// passed to MassDelete: EntityIDs and Fields, where an empty Fields means
+// every field and a LocaleNone Locale every locale.
+type AuditEntry struct {
+	At        time.Time        `json:"at"`
+	Action    AuditAction      `json:"action"`
+	Actor     string           `json:"actor,omitempty"`
+	RequestID string           `json:"request_id,omitempty"`
+	Entity    string           `json:"entity"`
+	Locale    Locale           `json:"locale"`
+	Keys      []TranslationKey `json:"keys,omitempty"`
+	EntityIDs []int            `json:"entity_ids,omitempty"`
+	Fields    []string         `json:"fields,omitempty"`
+}
+
+// AuditSink stores audit entries.
+type AuditSink interface {
+	WriteAudit(ctx context.Context, entry AuditEntry) error
+}
+
+// AuditContext is what an audit extractor takes from a write's context.
+type AuditContext struct {
+	Actor     string
+	RequestID string
+}
+
+type requestIDContextKey struct{}
+
+// WithRequestID returns a copy of ctx carrying the ID of the request that
+// makes changes with it, as recorded in audit entries.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID stored by WithRequestID, or "".
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey{}).(string)
+	return id
+}
+
+// AuditOptions configures NewAuditRepository.
+type AuditOptions struct {
+	// Extract takes the actor and request ID from a write's context, e.g.
+	// from your own authentication middleware. Defaults to ActorFromContext
+	// and RequestIDFromContext.
+	Extract func(ctx context.Context) AuditContext
+	// Now returns the entry timestamp. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// auditRepository writes an AuditEntry to a sink for every successful write.
+type auditRepository struct {
+	repo    TranslationRepository
+	sink    AuditSink
+	extract func(context.Context) AuditContext
+	now     func() time.Time
+}
+
+// NewAuditRepository wraps repo so that every successful MassCreateOrUpdate
+// and MassDelete is written to sink, one entry per entity:
+//
+//	sink := gotrans.NewJSONAuditSink(os.Stdout)
+//	repo := gotrans.NewAuditRepository(mysqlRepo, sink, gotrans.AuditOptions{})
+//	translator := gotrans.NewTranslator[Product](repo)
+//
+// A failure to write the entry is returned but doesn't undo the write.
+func NewAuditRepository(repo TranslationRepository, sink AuditSink, opts AuditOptions) TranslationRepository {
+	a := &auditRepository{repo: repo, sink: sink, extract: opts.Extract, now: opts.Now}
+	if a.extract == nil {
+		a.extract = func(ctx context.Context) AuditContext {
+			return AuditContext{Actor: ActorFromContext(ctx), RequestID: RequestIDFromContext(ctx)}
+		}
+	}
+	if a.now == nil {
+		a.now = time.Now
+	}
+	return a
+}
+
+func (a *auditRepository) GetTranslations(ctx context.Context, locale Locale, entity string, entityIDs []int) ([]Translation, error) {
+	return a.repo.GetTranslations(ctx, locale, entity, entityIDs)
+}
+
+func (a *auditRepository) MassCreateOrUpdate(ctx context.Context, locale Locale, translations []Translation) error {
+	if err := a.repo.MassCreateOrUpdate(ctx, locale, translations); err != nil || len(translations) == 0 {
+		return err
+	}
+
+	keys := make(map[string][]TranslationKey)
+	var entities []string
+	for _, tr := range translations {
+		if _, ok := keys[tr.Entity]; !ok {
+			entities = append(entities, tr.Entity)
+		}
+		keys[tr.Entity] = append(keys[tr.Entity], tr.Key())
+	}
+	sort.Strings(entities)
+
+	ac, at := a.extract(ctx), a.now()
+	for _, entity := range entities {
+		ks := keys[entity]
+		sort.Slice(ks, func(i, j int) bool { return revisionKeyLess(ks[i], ks[j]) })
+		err := a.sink.WriteAudit(ctx, AuditEntry{
+			At: at, Action: AuditSave, Actor: ac.Actor, RequestID: ac.RequestID,
+			Entity: entity, Locale: locale, Keys: ks,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *auditRepository) MassDelete(ctx context.Context, locale Locale, entity string, entityIDs []int, fields []string) error {
+	if err := a.repo.MassDelete(ctx, locale, entity, entityIDs, fields); err != nil {
+		return err
+	}
+	ac := a.extract(ctx)
+	return a.sink.WriteAudit(ctx, AuditEntry{
+		At: a.now(), Action: AuditDelete, Actor: ac.Actor, RequestID: ac.RequestID,
+		Entity: entity, Locale: locale, EntityIDs: entityIDs, Fields: fields,
+	})
+}
+
+// -----------------------------------------------------------------------------
+// Sinks
+// -----------------------------------------------------------------------------
+
+type jsonAuditSink struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewJSONAuditSink returns a sink that writes each entry to w as one line
+// of JSON. Writes are serialized, so w may be shared with other goroutines
+// only through this sink.
+func NewJSONAuditSink(w io.Writer) AuditSink {
+	return &jsonAuditSink{enc: json.NewEncoder(w)}
+}
+
+func (s *jsonAuditSink) WriteAudit(_ context.Context, entry AuditEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enc.Encode(entry)
+}
+
+type slogAuditSink struct {
+	logger *slog.Logger
+	level  slog.Level
+}
+
+// NewSlogAuditSink returns a sink that logs each entry to logger at level
+// with the message "translation change" and one attribute per entry field.
+func NewSlogAuditSink(logger *slog.Logger, level slog.Level) AuditSink {
+	return &slogAuditSink{logger: logger, level: level}
+}
+
+func (s *slogAuditSink) WriteAudit(ctx context.Context, entry AuditEntry) error {
+	attrs := []slog.Attr{
+		slog.String("action", string(entry.Action)),
+		slog.String("actor", entry.Actor),
+		slog.String("request_id", entry.RequestID),
+		slog.String("entity", entry.Entity),
+		slog.String("locale", entry.Locale.String()),
+	}
+	if entry.Keys != nil {
+		attrs = append(attrs, slog.Any("keys", entry.Keys))
+	}
+	if entry.EntityIDs != nil {
+		attrs = append(attrs, slog.Any("entity_ids", entry.EntityIDs))
+	}
+	if entry.Fields != nil {
+		attrs = append(attrs, slog.Any("fields", entry.Fields))
+	}
+	s.logger.LogAttrs(ctx, s.level, "translation change", attrs...)
+	return nil
+}
@@ -0,0 +1,73 @@
+package gotrans
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type auditRecorder struct{ entries []AuditEntry }
+
+func (r *auditRecorder) WriteAudit(_ context.Context, entry AuditEntry) error {
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func TestAuditRepository(t *testing.T) {
+	sink := &auditRecorder{}
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	repo := NewAuditRepository(&mockRepo{}, sink, AuditOptions{Now: func() time.Time { return now }})
+	trans := NewTranslator[Parameter](repo)
+	ctx := WithRequestID(WithActor(context.Background(), "alice"), "req-1")
+
+	entities := []Parameter{{ID: 2, locale: LocaleDE, Name: "Größe"}, {ID: 1, locale: LocaleDE, Name: "Farbe"}}
+	require.NoError(t, trans.SaveTranslationsWithOptions(ctx, entities, SaveOptions{Fields: []string{"name"}}))
+	require.NoError(t, trans.DeleteTranslationsByEntity(ctx, []int{1}))
+
+	require.Equal(t, []AuditEntry{
+		{
+			At: now, Action: AuditSave, Actor: "alice", RequestID: "req-1", Entity: "parameter", Locale: LocaleDE,
+			Keys: []TranslationKey{
+				{Entity: "parameter", EntityID: 1, Field: "name", Locale: LocaleDE},
+				{Entity: "parameter", EntityID: 2, Field: "name", Locale: LocaleDE},
+			},
+		},
+		{At: now, Action: AuditDelete, Actor: "alice", RequestID: "req-1", Entity: "parameter", EntityIDs: []int{1}},
+	}, sink.entries)
+
+	failing := NewAuditRepository(&mockRepo{deleteErr: ErrUnknownField}, sink, AuditOptions{
+		Extract: func(context.Context) AuditContext { return AuditContext{Actor: "svc"} },
+	})
+	require.Error(t, failing.MassDelete(ctx, LocaleDE, "parameter", []int{1}, nil))
+	require.Len(t, sink.entries, 2, "failed writes are not audited")
+}
+
+func TestAuditSinks(t *testing.T) {
+	entry := AuditEntry{
+		At: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), Action: AuditDelete, Actor: "bob",
+		Entity: "product", Locale: LocaleFR, EntityIDs: []int{7}, Fields: []string{"title"},
+	}
+
+	var buf bytes.Buffer
+	sink := NewJSONAuditSink(&buf)
+	require.NoError(t, sink.WriteAudit(context.Background(), entry))
+	require.NoError(t, sink.WriteAudit(context.Background(), entry))
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+	require.JSONEq(t, `{"at":"2026-01-02T03:04:05Z","action":"delete","actor":"bob","entity":"product","locale":"fr","entity_ids":[7],"fields":["title"]}`, string(lines[0]))
+
+	buf.Reset()
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	require.NoError(t, NewSlogAuditSink(logger, slog.LevelInfo).WriteAudit(context.Background(), entry))
+	var logged map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &logged))
+	require.Equal(t, "translation change", logged["msg"])
+	require.Equal(t, "bob", logged["actor"])
+	require.Equal(t, "fr", logged["locale"])
+	require.Equal(t, []any{float64(7)}, logged["entity_ids"])
+}
@@ -2,6 +2,7 @@ package gotrans
 
 import (
 	"context"
+	"errors"
 	"strconv"
 	"sync"
 	"time"
@@ -211,14 +212,20 @@ func (c *cachedRepository) GetTranslations(
 	if len(entityIDs) == 0 {
 		return nil, nil
 	}
+	if locale == LocaleNone {
+		// Rows of every locale; per-locale writes couldn't invalidate them.
+		return c.repo.GetTranslations(ctx, locale, entity, entityIDs)
+	}
 
 	var result []Translation
 	var missedIDs []int
+	hits := cacheHitRecorderFrom(ctx)
 
 	for _, id := range entityIDs {
 		key := translationCacheKey(locale, entity, id)
 		if cached, ok := c.cache.Get(key); ok {
 			result = append(result, cached...)
+			hits.record(key)
 		} else {
 			missedIDs = append(missedIDs, id)
 		}
@@ -284,9 +291,11 @@ func (c *cachedRepository) MassDelete(
 	entityIDs []int,
 	fields []string,
 ) error {
-	if err := c.repo.MassDelete(ctx, locale, entity, entityIDs, fields); err != nil {
+	err := c.repo.MassDelete(ctx, locale, entity, entityIDs, fields)
+	if err != nil && !errors.Is(err, ErrVersionConflict) {
 		return err
 	}
+	// On a version conflict the cached rows are stale as well.
 	if locale == LocaleNone {
 		// LocaleNone means all locales — use the entity index to invalidate every
 		// locale variant without knowing which locales were cached.
@@ -299,7 +308,7 @@ func (c *cachedRepository) MassDelete(
 		c.cache.Delete(keys...)
 		c.untrackKeys(entity, entityIDs, keys)
 	}
-	return nil
+	return err
 }
 
 func (c *cachedRepository) MassCreateOrUpdate(
@@ -308,6 +317,11 @@ func (c *cachedRepository) MassCreateOrUpdate(
 	translations []Translation,
 ) error {
 	if err := c.repo.MassCreateOrUpdate(ctx, locale, translations); err != nil {
+		if errors.Is(err, ErrVersionConflict) {
+			// The cached rows are what the caller loaded; let a reload see
+			// the newer versions.
+			c.invalidateByTranslations(translations)
+		}
 		return err
 	}
 	c.invalidateByTranslations(translations)
@@ -382,3 +396,49 @@ func translationCacheKey(locale Locale, entity string, entityID int) string {
 func entityIndexKey(entity string, entityID int) string {
 	return entity + ":" + strconv.Itoa(entityID)
 }
+
+// -----------------------------------------------------------------------------
+// Cache hit recording — lets the translator tell cache hits from reads
+// -----------------------------------------------------------------------------
+
+// cacheHitsKey is the context key under which a cacheHitRecorder is stored.
+type cacheHitsKey struct{}
+
+// cacheHitRecorder collects the cache keys a cached repository served without
+// reaching the underlying store. It is only attached to the context when a
+// provenance report is requested.
+type cacheHitRecorder struct {
+	mu   sync.Mutex
+	keys map[string]struct{}
+}
+
+func withCacheHitRecorder(ctx context.Context) (context.Context, *cacheHitRecorder) {
+	r := &cacheHitRecorder{keys: make(map[string]struct{})}
+	return context.WithValue(ctx, cacheHitsKey{}, r), r
+}
+
+func cacheHitRecorderFrom(ctx context.Context) *cacheHitRecorder {
+	r, _ := ctx.Value(cacheHitsKey{}).(*cacheHitRecorder)
+	return r
+}
+
+func (r *cacheHitRecorder) record(key string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.keys[key] = struct{}{}
+	r.mu.Unlock()
+}
+
+// hit reports whether the (locale, entity, entityID) entry was served from cache.
+// Safe to call on a nil recorder.
+func (r *cacheHitRecorder) hit(locale Locale, entity string, entityID int) bool {
+	if r == nil {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.keys[translationCacheKey(locale, entity, entityID)]
+	return ok
+}
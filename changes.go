@@ -0,0 +1,30 @@
+package gotrans
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrInvalidCursor is returned by ChangeFeed.Changes for a cursor it didn't
+// issue.
+var ErrInvalidCursor = errors.New("invalid change cursor")
+
+// Change is one entry of a change feed: a written translation or, when
+// Deleted, a tombstone of a deleted one.
+type Change struct {
+	// Translation is the row as written. A tombstone only carries the key
+	// fields and UpdatedAt, the time of the deletion.
+	Translation
+	Deleted bool
+}
+
+// ChangeFeed is implemented by repositories that let consumers such as
+// search indexers sync incrementally instead of re-reading everything.
+type ChangeFeed interface {
+	// Changes returns up to limit changes of entity, or of every entity for
+	// "", made after cursor, oldest first, and the cursor to resume from.
+	// The empty cursor starts at the beginning; a cursor is only valid with
+	// the entity it was issued for. A translation written several times
+	// since cursor appears once, as last written.
+	Changes(ctx context.Context, entity, cursor string, limit int) (changes []Change, next string, err error)
+}
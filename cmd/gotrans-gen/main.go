@@ -0,0 +1,318 @@
+// Command gotrans-gen generates reflection-free Translatable implementations.
+//
+// It reads `gotrans:"<field id>"` struct tags and emits TranslatableFields,
+// TranslationEntityName, typed getters/setters for every tagged field and the
+// gotrans.TranslationAccessor methods the translator uses to skip reflection.
+// TranslationEntityID and TranslationEntityLocale are left to the user, since
+// they depend on how the struct stores its identity.
+//
+// Tagged fields may be string, *string or gotrans.PluralText. A nil *string
+// produces no row on save, as with reflection. PluralText fields are listed in
+// TranslatableFields but get neither accessor cases nor typed methods, since
+// the translator always reads and writes them with reflection. []string and
+// sql.NullString-style wrappers need the translator's encoding and are
+// rejected; map such types with a hand-written TranslatableFields instead.
+//
+//	type Product struct {
+//		ID          int
+//		locale      gotrans.Locale
+//		Title       string `gotrans:"title"`
+//		Description string `gotrans:"description"`
+//	}
+//
+//	//go:generate gotrans-gen -type Product -entity product
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+const tagName = "gotrans"
+
+func main() {
+	var (
+		typeName   = flag.String("type", "", "struct type to generate methods for (required)")
+		entityName = flag.String("entity", "", "entity name stored in the translations table (default: snake_case of -type)")
+		output     = flag.String("output", "", "output file name (default: <type>_gotrans.go)")
+		dir        = flag.String("dir", ".", "package directory to scan")
+	)
+	flag.Parse()
+
+	if *typeName == "" {
+		fmt.Fprintln(os.Stderr, "gotrans-gen: -type is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *output == "" {
+		*output = toSnakeCase(*typeName) + "_gotrans.go"
+	}
+
+	src, err := generate(*dir, *typeName, *entityName, *output)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gotrans-gen: %v\n", err)
+		os.Exit(1)
+	}
+	if err = os.WriteFile(filepath.Join(*dir, *output), src, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "gotrans-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// fieldKind is how a tagged field is read and written by the accessors.
+type fieldKind uint8
+
+const (
+	kindString fieldKind = iota
+	kindStringPtr
+	kindPlural // left to the translator's reflection
+)
+
+// field describes one tagged struct field.
+type field struct {
+	Name string    // struct field name
+	ID   string    // DB field ID
+	Type string    // field type as written in the source
+	Kind fieldKind // how the accessors handle it
+}
+
+// spec is everything the template needs for one type.
+type spec struct {
+	Package string
+	Type    string
+	Entity  string
+	Fields  []field
+}
+
+// generate parses the package in dir, locates typeName and returns the
+// formatted source of the generated file. The output file itself is skipped
+// while parsing so that re-running the generator is idempotent.
+func generate(dir, typeName, entityName, output string) ([]byte, error) {
+	files, err := parsePackageFiles(dir, output)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		st := findStruct(f, typeName)
+		if st == nil {
+			continue
+		}
+		fields, err := collectFields(st)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", typeName, err)
+		}
+		if entityName == "" {
+			entityName = toSnakeCase(typeName)
+		}
+		return render(spec{Package: f.Name.Name, Type: typeName, Entity: entityName, Fields: fields})
+	}
+	return nil, fmt.Errorf("struct type %s not found in %s", typeName, dir)
+}
+
+// parsePackageFiles parses the Go files in dir except output.
+// Test files are included only when generating into a test file.
+func parsePackageFiles(dir, output string) ([]*ast.File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || name == output {
+			continue
+		}
+		if strings.HasSuffix(name, "_test.go") && !strings.HasSuffix(output, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+func findStruct(f *ast.File, typeName string) *ast.StructType {
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+		for _, s := range gd.Specs {
+			ts := s.(*ast.TypeSpec)
+			if ts.Name.Name != typeName {
+				continue
+			}
+			if st, ok := ts.Type.(*ast.StructType); ok {
+				return st
+			}
+		}
+	}
+	return nil
+}
+
+// collectFields returns tagged fields in declaration order.
+// Only string, *string and PluralText fields are accepted; anything else is an
+// error so that mistakes surface at generation time instead of as empty values
+// at runtime.
+func collectFields(st *ast.StructType) ([]field, error) {
+	var fields []field
+	seen := make(map[string]string)
+	for _, f := range st.Fields.List {
+		if f.Tag == nil {
+			continue
+		}
+		id, ok := reflect.StructTag(strings.Trim(f.Tag.Value, "`")).Lookup(tagName)
+		if !ok || id == "-" {
+			continue
+		}
+		if len(f.Names) != 1 {
+			return nil, errors.New("gotrans tag must be on a single named field")
+		}
+		name := f.Names[0].Name
+		if id == "" {
+			id = toSnakeCase(name)
+		}
+		kind, ok := fieldKindOf(f.Type)
+		if !ok {
+			return nil, fmt.Errorf("field %s: type %s is not supported; tagged fields must be string, *string or gotrans.PluralText "+
+				"([]string and sql.NullString-style fields need a hand-written TranslatableFields)", name, types.ExprString(f.Type))
+		}
+		if prev, dup := seen[id]; dup {
+			return nil, fmt.Errorf("fields %s and %s both map to %q", prev, name, id)
+		}
+		seen[id] = name
+		fields = append(fields, field{Name: name, ID: id, Type: types.ExprString(f.Type), Kind: kind})
+	}
+	if len(fields) == 0 {
+		return nil, errors.New("no fields tagged with `gotrans:\"...\"`")
+	}
+	return fields, nil
+}
+
+// fieldKindOf classifies a field type expression. The generator works on
+// syntax only, so PluralText is recognised by name.
+func fieldKindOf(expr ast.Expr) (fieldKind, bool) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		switch e.Name {
+		case "string":
+			return kindString, true
+		case "PluralText":
+			return kindPlural, true
+		}
+	case *ast.SelectorExpr:
+		return kindPlural, e.Sel.Name == "PluralText"
+	case *ast.StarExpr:
+		if ident, ok := e.X.(*ast.Ident); ok && ident.Name == "string" {
+			return kindStringPtr, true
+		}
+	}
+	return 0, false
+}
+
+func render(s spec) ([]byte, error) {
+	var b bytes.Buffer
+	w := func(format string, args ...any) { fmt.Fprintf(&b, format, args...) }
+
+	recv := strings.ToLower(s.Type[:1])
+	fieldsVar := lowerFirst(s.Type) + "TranslatableFields"
+
+	w("// Code generated by gotrans-gen. DO NOT EDIT.\n\n")
+	w("package %s\n\n", s.Package)
+
+	w("// %s is shared by every call to TranslatableFields; callers must not modify it.\n", fieldsVar)
+	w("var %s = map[string]string{\n", fieldsVar)
+	for _, f := range s.Fields {
+		w("\t%q: %q,\n", f.Name, f.ID)
+	}
+	w("}\n\n")
+
+	w("// TranslationEntityName returns the name stored in the translations table.\n")
+	w("func (%s) TranslationEntityName() string { return %q }\n\n", s.Type, s.Entity)
+
+	w("// TranslatableFields returns the struct field name → DB field ID mapping.\n")
+	w("func (%s) TranslatableFields() map[string]string { return %s }\n\n", s.Type, fieldsVar)
+
+	w("// TranslationFieldValue returns the value of the field mapped to the DB field ID.\n")
+	w("func (%s %s) TranslationFieldValue(field string) (string, bool) {\n", recv, s.Type)
+	w("\tswitch field {\n")
+	for _, f := range s.Fields {
+		switch f.Kind {
+		case kindString:
+			w("\tcase %q:\n\t\treturn %s.%s, true\n", f.ID, recv, f.Name)
+		case kindStringPtr:
+			w("\tcase %q:\n\t\tif %s.%s == nil {\n\t\t\treturn \"\", false\n\t\t}\n\t\treturn *%s.%s, true\n", f.ID, recv, f.Name, recv, f.Name)
+		}
+	}
+	w("\t}\n\treturn \"\", false\n}\n\n")
+
+	w("// SetTranslationFieldValue sets the field mapped to the DB field ID.\n")
+	w("func (%s *%s) SetTranslationFieldValue(field, value string) bool {\n", recv, s.Type)
+	w("\tswitch field {\n")
+	for _, f := range s.Fields {
+		switch f.Kind {
+		case kindString:
+			w("\tcase %q:\n\t\t%s.%s = value\n\t\treturn true\n", f.ID, recv, f.Name)
+		case kindStringPtr:
+			w("\tcase %q:\n\t\t%s.%s = &value\n\t\treturn true\n", f.ID, recv, f.Name)
+		}
+	}
+	w("\t}\n\treturn false\n}\n")
+
+	for _, f := range s.Fields {
+		if f.Kind == kindPlural {
+			continue // would need the gotrans import; the field is set directly
+		}
+		w("\n// Translated%s returns the %q translation.\n", f.Name, f.ID)
+		w("func (%s %s) Translated%s() %s { return %s.%s }\n", recv, s.Type, f.Name, f.Type, recv, f.Name)
+		w("\n// SetTranslated%s sets the %q translation.\n", f.Name, f.ID)
+		w("func (%s *%s) SetTranslated%s(value %s) { %s.%s = value }\n", recv, s.Type, f.Name, f.Type, recv, f.Name)
+	}
+
+	return format.Source(b.Bytes())
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// toSnakeCase mirrors gotrans.toSnakeCase so that default entity and field
+// names match what the library's reflection helpers would produce.
+func toSnakeCase(str string) string {
+	var result []rune
+	runes := []rune(str)
+	n := len(runes)
+	for i := 0; i < n; i++ {
+		if i > 0 && isUpper(runes[i]) && (i+1 < n && !isUpper(runes[i+1]) || !isUpper(runes[i-1])) {
+			result = append(result, '_')
+		}
+		result = append(result, toLower(runes[i]))
+	}
+	return string(result)
+}
+
+func isUpper(r rune) bool { return r >= 'A' && r <= 'Z' }
+func toLower(r rune) rune {
+	if isUpper(r) {
+		return r + ('a' - 'A')
+	}
+	return r
+}
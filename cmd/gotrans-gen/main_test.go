@@ -0,0 +1,82 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate_Golden(t *testing.T) {
+	for _, typ := range []string{"Product", "Video", "Listing"} {
+		t.Run(typ, func(t *testing.T) {
+			name := strings.ToLower(typ) + "_gotrans"
+			got, err := generate("testdata", typ, "", name+".go")
+			require.NoError(t, err)
+
+			want, err := os.ReadFile(filepath.Join("testdata", name+".golden"))
+			require.NoError(t, err)
+			require.Equal(t, string(want), string(got))
+		})
+	}
+}
+
+// TestGenerate_CheckedInOutput keeps the generated file used by the gotrans
+// tests in sync with the generator.
+func TestGenerate_CheckedInOutput(t *testing.T) {
+	root := filepath.Join("..", "..")
+	got, err := generate(root, "Article", "article", "article_gotrans_test.go")
+	require.NoError(t, err)
+
+	want, err := os.ReadFile(filepath.Join(root, "article_gotrans_test.go"))
+	require.NoError(t, err)
+	require.Equal(t, string(want), string(got), "run go generate in the module root")
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		err  string
+	}{
+		{
+			name: "non-string field",
+			src:  "package p\ntype E struct{ N int `gotrans:\"n\"` }\n",
+			err:  "type int is not supported",
+		},
+		{
+			name: "string list field",
+			src:  "package p\ntype E struct{ Tags []string `gotrans:\"tags\"` }\n",
+			err:  "type []string is not supported",
+		},
+		{
+			name: "duplicate field ID",
+			src:  "package p\ntype E struct{ A string `gotrans:\"x\"`; B string `gotrans:\"x\"` }\n",
+			err:  `both map to "x"`,
+		},
+		{
+			name: "no tagged fields",
+			src:  "package p\ntype E struct{ A string }\n",
+			err:  "no fields tagged",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "e.go"), []byte(tt.src), 0o644))
+			_, err := generate(dir, "E", "e", "e_gotrans.go")
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+
+	_, err := generate("testdata", "Missing", "", "missing_gotrans.go")
+	require.ErrorContains(t, err, "not found")
+}
+
+func TestToSnakeCase(t *testing.T) {
+	require.Equal(t, "ai_recommends", toSnakeCase("AIRecommends"))
+	require.Equal(t, "some_field", toSnakeCase("SomeField"))
+	require.Equal(t, "product", toSnakeCase("Product"))
+}
@@ -0,0 +1,10 @@
+package catalog
+
+import "github.com/ivan-gorbushko/gotrans"
+
+type Listing struct {
+	ID       int
+	Title    string             `gotrans:"title"`
+	Subtitle *string            `gotrans:"subtitle"`
+	Stock    gotrans.PluralText `gotrans:"stock"`
+}
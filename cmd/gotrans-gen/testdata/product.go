@@ -0,0 +1,10 @@
+package catalog
+
+type Product struct {
+	ID          int
+	Title       string `gotrans:"title"`
+	Description string `gotrans:"desc"`
+	ShortName   string `gotrans:""`
+	Internal    string `gotrans:"-"`
+	SKU         string
+}
@@ -0,0 +1,6 @@
+package catalog
+
+type Video struct {
+	ID    int
+	Title string `gotrans:"title"`
+}
@@ -0,0 +1,74 @@
+// Command gotrans-vet runs the gotrans analyzer, standalone or as a vet tool:
+//
+//	gotrans-vet ./...
+//	go vet -vettool=$(which gotrans-vet) ./...
+//
+// go vet analyzes one package at a time, so it compares entity names only
+// along imports. Run standalone on package patterns, gotrans-vet loads them
+// together, test files included, and also reports entity names shared by
+// packages that don't import each other (see analyzer.CheckModule). With
+// flags it runs as a plain single-analyzer checker.
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/ivan-gorbushko/gotrans/analyzer"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/analysis/singlechecker"
+	"golang.org/x/tools/go/packages"
+)
+
+func main() {
+	args := os.Args[1:]
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") || strings.HasSuffix(args[len(args)-1], ".cfg") {
+		// go vet's tool protocol, flags and usage.
+		singlechecker.Main(analyzer.Analyzer)
+	}
+	log.SetFlags(0)
+	log.SetPrefix(analyzer.Analyzer.Name + ": ")
+	os.Exit(checkModule(args))
+}
+
+// checkModule analyzes the packages matching patterns and returns the exit
+// code: 0 when clean, 1 on load or analysis errors, 3 when something was
+// reported, as singlechecker does.
+func checkModule(patterns []string) int {
+	cfg := &packages.Config{Mode: packages.LoadAllSyntax | packages.NeedModule, Tests: true}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		log.Print(err)
+		return 1
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return 1
+	}
+	graph, err := checker.Analyze([]*analysis.Analyzer{analyzer.Analyzer}, pkgs, nil)
+	if err != nil {
+		log.Print(err)
+		return 1
+	}
+	if err = graph.PrintText(os.Stderr, -1); err != nil {
+		log.Print(err)
+		return 1
+	}
+
+	code := 0
+	for act := range graph.All() {
+		if act.Err != nil {
+			return 1
+		}
+		if act.IsRoot && len(act.Diagnostics) > 0 {
+			code = 3
+		}
+	}
+	for _, c := range analyzer.CheckModule(graph) {
+		fmt.Fprintln(os.Stderr, c)
+		code = 3
+	}
+	return code
+}
@@ -0,0 +1,126 @@
+package gotrans
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrVersionConflict is matched by a *VersionConflictError.
+var ErrVersionConflict = errors.New("version conflict")
+
+// VersionConflict is one translation whose stored version moved on.
+type VersionConflict struct {
+	TranslationKey
+	// Expected is the version the caller loaded, 0 if it loaded none.
+	Expected int64
+	// Actual is the stored version, 0 if the translation was deleted.
+	Actual int64
+}
+
+// VersionConflictError is returned by a conditional save when translations
+// changed since they were loaded. It matches ErrVersionConflict and is
+// classified as KindConflict.
+//
+// The repository call that returns it writes nothing. A conditional save
+// checks every version before its first write, so a save from a stale load
+// writes nothing either. A save spans one write per locale and one delete per
+// field, though, and is not a transaction: a change that lands between the
+// check and a later call fails that call after the earlier ones were written.
+type VersionConflictError struct {
+	Conflicts []VersionConflict
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("%s: stale fields %s", ErrVersionConflict, strings.Join(e.Fields(), ", "))
+}
+
+func (e *VersionConflictError) Is(target error) bool { return target == ErrVersionConflict }
+
+// Fields returns the distinct DB field IDs in conflict, sorted, with the
+// categories of PluralText fields folded into the field.
+func (e *VersionConflictError) Fields() []string {
+	seen := make(map[string]struct{}, len(e.Conflicts))
+	var fields []string
+	for _, c := range e.Conflicts {
+		field, _, _ := splitPluralFieldID(c.Field)
+		if _, ok := seen[field]; !ok {
+			seen[field] = struct{}{}
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// TranslationVersioned is an optional interface for entities that carry the
+// stored versions of their translations from LoadTranslations to a
+// conditional save (SaveOptions.Conditional). It is detected on *T; embed
+// VersionTracking to implement it.
+type TranslationVersioned interface {
+	// TranslationVersions returns the versions recorded by the last load,
+	// keyed by stored field ID.
+	TranslationVersions() map[string]int64
+	// SetTranslationVersions records the versions of the rows a load found
+	// in the entity's own locale.
+	SetTranslationVersions(versions map[string]int64)
+}
+
+// VersionTracking implements TranslationVersioned for embedding:
+//
+//	type Product struct {
+//		gotrans.VersionTracking
+//		ID    int
+//		Title string
+//	}
+type VersionTracking struct {
+	versions map[string]int64
+}
+
+func (v *VersionTracking) TranslationVersions() map[string]int64 { return v.versions }
+
+func (v *VersionTracking) SetTranslationVersions(versions map[string]int64) {
+	v.versions = versions
+}
+
+type versionCheckContextKey struct{}
+
+// WithVersionCheck returns a copy of ctx that makes MassCreateOrUpdate
+// conditional: each Translation.Version is the stored version the caller
+// expects to replace, 0 for none. The translator sets it for
+// SaveOptions.Conditional; repositories call VersionCheckFromContext.
+func WithVersionCheck(ctx context.Context) context.Context {
+	return context.WithValue(ctx, versionCheckContextKey{}, true)
+}
+
+// VersionCheckFromContext reports whether ctx was made by WithVersionCheck.
+// A repository that stores versions must then compare them in the same
+// transaction as the write and fail with a *VersionConflictError listing
+// every mismatch, writing nothing.
+func VersionCheckFromContext(ctx context.Context) bool {
+	check, _ := ctx.Value(versionCheckContextKey{}).(bool)
+	return check
+}
+
+type deleteVersionsContextKey struct{}
+
+// WithDeleteVersions returns a copy of ctx that makes MassDelete
+// conditional: versions holds, for every key the call deletes, the stored
+// version the caller expects to remove, 0 for none. The translator sets it
+// for deletes made by a SaveOptions.Conditional save under
+// EmptyValueDelete; repositories call DeleteVersionsFromContext.
+func WithDeleteVersions(ctx context.Context, versions map[TranslationKey]int64) context.Context {
+	return context.WithValue(ctx, deleteVersionsContextKey{}, versions)
+}
+
+// DeleteVersionsFromContext returns the versions set by WithDeleteVersions.
+// A repository that stores versions must then compare every row the delete
+// would remove, and every key in versions, in the same transaction as the
+// delete and fail with a *VersionConflictError listing every mismatch,
+// deleting nothing. Rows missing from versions are expected not to exist.
+func DeleteVersionsFromContext(ctx context.Context) (map[TranslationKey]int64, bool) {
+	versions, ok := ctx.Value(deleteVersionsContextKey{}).(map[TranslationKey]int64)
+	return versions, ok
+}
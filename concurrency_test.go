@@ -0,0 +1,91 @@
+package gotrans
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type versionedParameter struct {
+	VersionTracking
+	ID          int
+	Name        string
+	Description string
+}
+
+func (p versionedParameter) TranslationEntityLocale() Locale { return LocaleEN }
+func (p versionedParameter) TranslationEntityID() int        { return p.ID }
+func (p versionedParameter) TranslatableFields() map[string]string {
+	return map[string]string{"Name": "name", "Description": "description"}
+}
+func (p versionedParameter) TranslationEntityName() string { return "versioned_parameter" }
+
+// versionedRepo is a savedRepo that tracks and checks versions.
+type versionedRepo struct{ savedRepo }
+
+func (r *versionedRepo) MassCreateOrUpdate(ctx context.Context, locale Locale, translations []Translation) error {
+	r.mu.Lock()
+	stored := make(map[TranslationKey]int64, len(r.saved))
+	for _, tr := range r.saved {
+		stored[tr.Key()] = tr.Version
+	}
+	r.mu.Unlock()
+
+	var conflicts []VersionConflict
+	trs := make([]Translation, len(translations))
+	for i, tr := range translations {
+		actual := stored[tr.Key()]
+		if VersionCheckFromContext(ctx) && tr.Version != actual {
+			conflicts = append(conflicts, VersionConflict{TranslationKey: tr.Key(), Expected: tr.Version, Actual: actual})
+		}
+		tr.Version = actual + 1
+		trs[i] = tr
+	}
+	if conflicts != nil {
+		return &VersionConflictError{Conflicts: conflicts}
+	}
+	return r.savedRepo.MassCreateOrUpdate(ctx, locale, trs)
+}
+
+func TestTranslator_ConditionalSave(t *testing.T) {
+	repo := &versionedRepo{}
+	trans := NewTranslator[*versionedParameter](repo)
+	ctx := context.Background()
+
+	require.NoError(t, trans.SaveTranslations(ctx, []*versionedParameter{{ID: 1, Name: "Size", Description: "Box size"}}))
+
+	load := func() *versionedParameter {
+		got, err := trans.LoadTranslations(ctx, []*versionedParameter{{ID: 1}})
+		require.NoError(t, err)
+		return got[0]
+	}
+	alice, bob := load(), load()
+	require.Equal(t, map[string]int64{"name": 1, "description": 1}, alice.TranslationVersions())
+
+	conditional := SaveOptions{Conditional: true}
+	alice.Name = "Dimensions"
+	require.NoError(t, trans.SaveTranslationsWithOptions(ctx, []*versionedParameter{alice}, SaveOptions{Conditional: true, Fields: []string{"name"}}))
+
+	bob.Description = "Size of the box"
+	err := trans.SaveTranslationsWithOptions(ctx, []*versionedParameter{bob}, conditional)
+	require.ErrorIs(t, err, ErrVersionConflict)
+	require.Equal(t, KindConflict, KindOf(err))
+	var conflict *VersionConflictError
+	require.True(t, errors.As(err, &conflict))
+	require.Equal(t, []string{"name"}, conflict.Fields())
+	require.Equal(t, int64(1), conflict.Conflicts[0].Expected)
+	require.Equal(t, int64(2), conflict.Conflicts[0].Actual)
+
+	bob = load()
+	bob.Description = "Size of the box"
+	require.NoError(t, trans.SaveTranslationsWithOptions(ctx, []*versionedParameter{bob}, conditional), "a reload picks up the new versions")
+
+	err = trans.SaveTranslationsWithOptions(ctx, []*versionedParameter{{ID: 1, Name: "Blind"}}, conditional)
+	require.ErrorIs(t, err, ErrVersionConflict, "an entity that wasn't loaded expects no stored rows")
+
+	err = NewTranslator[Parameter](repo).SaveTranslationsWithOptions(ctx, []Parameter{{ID: 1}}, conditional)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrVersionConflict)
+}
@@ -0,0 +1,182 @@
+package gotrans
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ParseMode selects how ParseLocaleMode treats codes ParseLocale rejects.
+type ParseMode uint8
+
+const (
+	// ParseStrict fails with ErrUnknownLocale.
+	ParseStrict ParseMode = iota
+	// ParseLenient drops trailing subtags until a known locale remains,
+	// so "en-US-x-twain" yields en-US and "en-ZZ" yields en, and yields
+	// LocaleNone without error when none does.
+	ParseLenient
+)
+
+// ParseLocaleMode is ParseLocale with an error. The empty string and "none"
+// parse as LocaleNone in both modes.
+func ParseLocaleMode(code string, mode ParseMode) (Locale, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return LocaleNone, nil
+	}
+	if l, ok := ParseLocale(code); ok {
+		return l, nil
+	}
+	if mode == ParseLenient {
+		prefix := strings.ReplaceAll(code, "_", "-")
+		for i := strings.LastIndexByte(prefix, '-'); i > 0; i = strings.LastIndexByte(prefix, '-') {
+			prefix = prefix[:i]
+			if l, ok := ParseLocale(prefix); ok {
+				return l, nil
+			}
+		}
+		return LocaleNone, nil
+	}
+	return LocaleNone, fmt.Errorf("%w %q", ErrUnknownLocale, code)
+}
+
+// Locale encodes as its canonical tag in text, JSON and SQL, with LocaleNone
+// as "" in text and JSON and as NULL in SQL. Decoding is strict: unknown
+// codes fail with ErrUnknownLocale. Use LenientLocale to accept them.
+var (
+	_ json.Marshaler   = Locale(0)
+	_ json.Unmarshaler = (*Locale)(nil)
+	_ driver.Valuer    = Locale(0)
+)
+
+// MarshalText implements encoding.TextMarshaler.
+func (l Locale) MarshalText() ([]byte, error) {
+	if l == LocaleNone {
+		return []byte{}, nil
+	}
+	code := l.Code()
+	if code == "" {
+		return nil, fmt.Errorf("gotrans: cannot marshal unknown Locale(%d)", int16(l))
+	}
+	return []byte(code), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (l *Locale) UnmarshalText(text []byte) error {
+	return l.decode(string(text), ParseStrict)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (l Locale) MarshalJSON() ([]byte, error) {
+	text, err := l.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It accepts a string or null.
+func (l *Locale) UnmarshalJSON(data []byte) error {
+	return l.decodeJSON(data, ParseStrict)
+}
+
+// Value implements driver.Valuer.
+func (l Locale) Value() (driver.Value, error) {
+	if l == LocaleNone {
+		return nil, nil
+	}
+	text, err := l.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return string(text), nil
+}
+
+// Scan implements sql.Scanner. It accepts a string, []byte or NULL.
+func (l *Locale) Scan(src any) error {
+	return l.scan(src, ParseStrict)
+}
+
+func (l *Locale) decode(code string, mode ParseMode) error {
+	parsed, err := ParseLocaleMode(code, mode)
+	if err != nil {
+		return err
+	}
+	*l = parsed
+	return nil
+}
+
+func (l *Locale) decodeJSON(data []byte, mode ParseMode) error {
+	if bytes.Equal(data, []byte("null")) {
+		*l = LocaleNone
+		return nil
+	}
+	var code string
+	if err := json.Unmarshal(data, &code); err != nil {
+		if mode == ParseLenient {
+			// Locales used to encode as their number.
+			var n int16
+			if json.Unmarshal(data, &n) == nil {
+				if _, ok := Locale(n).info(); ok {
+					*l = Locale(n)
+					return nil
+				}
+			}
+			*l = LocaleNone
+			return nil
+		}
+		return fmt.Errorf("gotrans: locale must be a JSON string: %w", err)
+	}
+	return l.decode(code, mode)
+}
+
+func (l *Locale) scan(src any, mode ParseMode) error {
+	switch v := src.(type) {
+	case nil:
+		*l = LocaleNone
+		return nil
+	case string:
+		return l.decode(v, mode)
+	case []byte:
+		return l.decode(string(v), mode)
+	}
+	return fmt.Errorf("gotrans: cannot scan %T into Locale", src)
+}
+
+// LenientLocale is a Locale that decodes with ParseLenient: unknown codes
+// fall back to a known prefix or LocaleNone instead of failing, and JSON
+// numbers of known locales are accepted. Use it for input you don't control,
+// such as query parameters or legacy columns. It encodes like Locale.
+type LenientLocale Locale
+
+// Locale returns l as a Locale.
+func (l LenientLocale) Locale() Locale { return Locale(l) }
+
+func (l LenientLocale) String() string { return Locale(l).String() }
+
+// MarshalText implements encoding.TextMarshaler.
+func (l LenientLocale) MarshalText() ([]byte, error) { return Locale(l).MarshalText() }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (l *LenientLocale) UnmarshalText(text []byte) error {
+	return (*Locale)(l).decode(string(text), ParseLenient)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (l LenientLocale) MarshalJSON() ([]byte, error) { return Locale(l).MarshalJSON() }
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (l *LenientLocale) UnmarshalJSON(data []byte) error {
+	return (*Locale)(l).decodeJSON(data, ParseLenient)
+}
+
+// Value implements driver.Valuer.
+func (l LenientLocale) Value() (driver.Value, error) { return Locale(l).Value() }
+
+// Scan implements sql.Scanner.
+func (l *LenientLocale) Scan(src any) error {
+	return (*Locale)(l).scan(src, ParseLenient)
+}
@@ -0,0 +1,89 @@
+package gotrans
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLocaleMode(t *testing.T) {
+	cases := []struct {
+		code      string
+		strict    Locale
+		strictErr bool
+		lenient   Locale
+	}{
+		{"", LocaleNone, false, LocaleNone},
+		{"none", LocaleNone, false, LocaleNone},
+		{"pt_br", LocalePTBR, false, LocalePTBR},
+		{"en-US-x-twain", LocaleNone, true, LocaleENUS},
+		{"en-ZZ", LocaleNone, true, LocaleEN},
+		{"klingon", LocaleNone, true, LocaleNone},
+	}
+	for _, c := range cases {
+		l, err := ParseLocaleMode(c.code, ParseStrict)
+		if c.strictErr {
+			require.ErrorIs(t, err, ErrUnknownLocale, c.code)
+		} else {
+			require.NoError(t, err, c.code)
+		}
+		require.Equal(t, c.strict, l, c.code)
+
+		l, err = ParseLocaleMode(c.code, ParseLenient)
+		require.NoError(t, err, c.code)
+		require.Equal(t, c.lenient, l, c.code)
+	}
+}
+
+func TestLocale_JSON(t *testing.T) {
+	type dto struct {
+		Locale   Locale            `json:"locale"`
+		Fallback Locale            `json:"fallback"`
+		Names    map[Locale]string `json:"names"`
+	}
+	data, err := json.Marshal(dto{Locale: LocaleENGB, Names: map[Locale]string{LocaleDE: "Name"}})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"locale":"en-GB","fallback":"","names":{"de":"Name"}}`, string(data))
+
+	var got dto
+	require.NoError(t, json.Unmarshal([]byte(`{"locale":"zh_hant","fallback":null,"names":{"FR":"Nom"}}`), &got))
+	require.Equal(t, dto{Locale: LocaleZHHant, Names: map[Locale]string{LocaleFR: "Nom"}}, got)
+
+	require.ErrorIs(t, json.Unmarshal([]byte(`{"locale":"xx"}`), &got), ErrUnknownLocale)
+	require.Error(t, json.Unmarshal([]byte(`{"locale":11}`), &got))
+}
+
+func TestLenientLocale(t *testing.T) {
+	var in struct {
+		A, B, C LenientLocale
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"A":"en-ZZ","B":"xx","C":11}`), &in))
+	require.Equal(t, LocaleEN, in.A.Locale())
+	require.Equal(t, LocaleNone, in.B.Locale())
+	require.Equal(t, LocaleEN, in.C.Locale(), "numbers of known locales are accepted")
+
+	var l LenientLocale
+	require.NoError(t, l.UnmarshalText([]byte("de_AT_1996")))
+	require.Equal(t, "de", l.String(), "unregistered variants fall back to a known parent")
+	require.NoError(t, l.Scan([]byte("??")))
+	require.Equal(t, LocaleNone, l.Locale())
+}
+
+func TestLocale_SQL(t *testing.T) {
+	v, err := LocaleSRLatn.Value()
+	require.NoError(t, err)
+	require.Equal(t, driver.Value("sr-Latn"), v)
+	v, err = LocaleNone.Value()
+	require.NoError(t, err)
+	require.Nil(t, v)
+
+	var l Locale
+	require.NoError(t, l.Scan([]byte("uk")))
+	require.Equal(t, LocaleUK, l)
+	require.NoError(t, l.Scan(nil))
+	require.Equal(t, LocaleNone, l)
+	require.ErrorIs(t, l.Scan("xx"), ErrUnknownLocale)
+	require.Error(t, l.Scan(int64(3)))
+}
@@ -0,0 +1,136 @@
+package gotrans
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// ErrorKind classifies an error so callers can react without string matching.
+type ErrorKind uint8
+
+const (
+	KindUnknown    ErrorKind = iota
+	KindNotFound             // the requested row does not exist
+	KindConflict             // constraint violation or concurrent modification
+	KindValidation           // bad input: invalid field, locale, value or entity definition
+	KindTransient            // timeout, deadlock, lost connection: retrying may succeed
+	KindCanceled             // the caller canceled the context
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case KindNotFound:
+		return "not found"
+	case KindConflict:
+		return "conflict"
+	case KindValidation:
+		return "validation"
+	case KindTransient:
+		return "transient"
+	case KindCanceled:
+		return "canceled"
+	}
+	return "unknown"
+}
+
+// Error is the error type returned by the translator and the bundled
+// repositories. It records what was being done and to which rows, and
+// classifies the cause. Use errors.As to inspect it, or KindOf for the class.
+type Error struct {
+	Op     string // operation, e.g. "SaveTranslations" or "translationRepository.GetTranslations"
+	Entity string
+	Locale Locale // LocaleNone when not specific to one locale
+	IDs    []int  // affected entity IDs, if known
+	Kind   ErrorKind
+	Err    error // underlying cause
+}
+
+// maxErrorIDs caps how many IDs Error() prints.
+const maxErrorIDs = 10
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	b.WriteString("gotrans: ")
+	b.WriteString(e.Op)
+	if e.Entity != "" {
+		b.WriteString(" " + e.Entity)
+	}
+	if e.Locale != LocaleNone {
+		b.WriteString(" locale=" + e.Locale.String())
+	}
+	if len(e.IDs) > 0 {
+		ids := e.IDs
+		if len(ids) > maxErrorIDs {
+			ids = ids[:maxErrorIDs]
+		}
+		fmt.Fprintf(&b, " ids=%v", ids)
+		if len(e.IDs) > maxErrorIDs {
+			fmt.Fprintf(&b, "(+%d)", len(e.IDs)-maxErrorIDs)
+		}
+	}
+	if e.Kind != KindUnknown {
+		b.WriteString(": " + e.Kind.String())
+	}
+	if e.Err != nil {
+		b.WriteString(": " + e.Err.Error())
+	}
+	return b.String()
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// KindOf returns the class of err: the Kind of the first classified *Error in
+// its chain, otherwise a best-effort classification of well-known errors
+// (context, database/sql, network timeouts and this package's sentinels).
+func KindOf(err error) ErrorKind {
+	for e := err; e != nil; {
+		var ge *Error
+		if !errors.As(e, &ge) {
+			break
+		}
+		if ge.Kind != KindUnknown {
+			return ge.Kind
+		}
+		e = ge.Err
+	}
+
+	var ve *ValidationError
+	var ne net.Error
+	switch {
+	case err == nil:
+		return KindUnknown
+	case errors.Is(err, context.Canceled):
+		return KindCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return KindTransient
+	case errors.Is(err, ErrEmptyEntityName), errors.Is(err, ErrUnknownField), errors.Is(err, ErrUnknownLocale),
+		errors.Is(err, ErrRevisionMismatch), errors.Is(err, ErrInvalidCursor), errors.Is(err, ErrUnknownPluralCategory),
+		errors.As(err, &ve):
+		return KindValidation
+	case errors.Is(err, ErrInvalidTransition), errors.Is(err, ErrVersionConflict):
+		return KindConflict
+	case errors.Is(err, sql.ErrNoRows), errors.Is(err, ErrTranslationNotFound):
+		return KindNotFound
+	case errors.Is(err, driver.ErrBadConn), errors.As(err, &ne) && ne.Timeout():
+		return KindTransient
+	}
+	return KindUnknown
+}
+
+// wrapError returns err as an *Error for op. An error that already carries an
+// *Error, e.g. from a repository, is returned unchanged so its details survive.
+func wrapError(op, entity string, locale Locale, ids []int, err error) error {
+	if err == nil {
+		return nil
+	}
+	var ge *Error
+	if errors.As(err, &ge) {
+		return err
+	}
+	return &Error{Op: op, Entity: entity, Locale: locale, IDs: ids, Kind: KindOf(err), Err: err}
+}
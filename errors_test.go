@@ -0,0 +1,64 @@
+package gotrans
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKindOf(t *testing.T) {
+	require.Equal(t, KindUnknown, KindOf(nil))
+	require.Equal(t, KindUnknown, KindOf(errTest))
+	require.Equal(t, KindCanceled, KindOf(context.Canceled))
+	require.Equal(t, KindTransient, KindOf(context.DeadlineExceeded))
+	require.Equal(t, KindValidation, KindOf(ErrEmptyEntityName))
+	require.Equal(t, KindValidation, KindOf(&ValidationError{}))
+	require.Equal(t, KindConflict, KindOf(&Error{Op: "x", Kind: KindConflict, Err: errTest}))
+	require.Equal(t, KindCanceled, KindOf(&Error{Op: "x", Err: context.Canceled}), "unclassified *Error falls back to its cause")
+}
+
+func TestError_Message(t *testing.T) {
+	err := &Error{Op: "SaveTranslations", Entity: "product", Locale: LocaleFR,
+		IDs: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Kind: KindTransient, Err: errTest}
+	require.Equal(t, "gotrans: SaveTranslations product locale=fr ids=[1 2 3 4 5 6 7 8 9 10](+2): transient: "+errTest.Error(), err.Error())
+}
+
+func TestTranslator_WrapsErrors(t *testing.T) {
+	trans := NewTranslator[Parameter](&mockRepo{getErr: errTest})
+
+	_, err := trans.LoadTranslations(context.Background(), []Parameter{{ID: 7, locale: LocaleEN}})
+	var ge *Error
+	require.ErrorAs(t, err, &ge)
+	require.Equal(t, "LoadTranslations", ge.Op)
+	require.Equal(t, "parameter", ge.Entity)
+	require.Equal(t, LocaleEN, ge.Locale)
+	require.Equal(t, []int{7}, ge.IDs)
+	require.ErrorIs(t, err, errTest)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = trans.SaveTranslations(ctx, []Parameter{{ID: 1, locale: LocaleEN}})
+	require.Equal(t, KindCanceled, KindOf(err))
+
+	err = trans.SaveTranslationsWithOptions(context.Background(), []Parameter{{ID: 1, locale: LocaleEN}}, SaveOptions{Fields: []string{"nope"}})
+	require.Equal(t, KindValidation, KindOf(err))
+	require.ErrorIs(t, err, ErrUnknownField)
+}
+
+func TestTranslator_PropagatesRepositoryErrorsIntact(t *testing.T) {
+	repoErr := &Error{Op: "translationRepository.MassCreateOrUpdate", Entity: "parameter", Locale: LocaleEN, IDs: []int{1}, Kind: KindConflict, Err: errTest}
+	cached := NewCachedRepository(&mockRepo{saveErr: repoErr, getErr: repoErr}, NewInMemoryCache(), CacheOptions{})
+	trans := NewTranslator[Parameter](cached)
+
+	err := trans.SaveTranslations(context.Background(), []Parameter{{ID: 1, locale: LocaleEN, Name: "x"}})
+	var ge *Error
+	require.True(t, errors.As(err, &ge))
+	require.Same(t, repoErr, ge, "the repository's *Error passes through the cache and the translator unchanged")
+
+	_, err = trans.LoadTranslations(context.Background(), []Parameter{{ID: 1, locale: LocaleEN}})
+	require.True(t, errors.As(err, &ge))
+	require.Same(t, repoErr, ge)
+	require.Equal(t, KindConflict, KindOf(err))
+}
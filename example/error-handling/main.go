@@ -83,7 +83,7 @@ func main() {
 	toLoad := []Product{{ID: 1, locale: gotrans.LocaleEN}}
 	_, err = translator.LoadTranslations(ctx, toLoad)
 	if err != nil {
-		fmt.Printf("✓ Caught error from cancelled context: %v\n", err)
+		fmt.Printf("✓ Caught error from cancelled context: %v (kind: %s)\n", err, gotrans.KindOf(err))
 	}
 
 	// Example 2: Handle context timeout
@@ -97,7 +97,7 @@ func main() {
 
 	_, err = slowTranslator.LoadTranslations(ctx, toLoad)
 	if err != nil {
-		fmt.Printf("✓ Caught timeout error: %v\n", err)
+		fmt.Printf("✓ Caught timeout error: %v (kind: %s)\n", err, gotrans.KindOf(err))
 	}
 
 	// Example 3: Empty entity name handling
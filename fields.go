@@ -0,0 +1,162 @@
+package gotrans
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+)
+
+// fieldKind describes how a translatable struct field maps to Translation.Value.
+type fieldKind uint8
+
+const (
+	// kindString is a plain string (or named string type) field.
+	kindString fieldKind = iota + 1
+	// kindStringPtr is an optional *string; nil means "no translation",
+	// distinct from a pointer to "".
+	kindStringPtr
+	// kindStringSlice is a []string stored as a JSON-encoded list.
+	kindStringSlice
+	// kindNullable is a sql.NullString-style wrapper: the field type implements
+	// driver.Valuer and its pointer implements sql.Scanner. A nil driver value
+	// means "no translation".
+	kindNullable
+	// kindPlural is a PluralText; each variant is stored as its own row under
+	// a sub-field ID, see PluralFieldID.
+	kindPlural
+)
+
+var (
+	valuerType     = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	scannerType    = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	pluralTextType = reflect.TypeOf(PluralText(nil))
+)
+
+// fieldInfo locates a translatable field and how to encode it.
+type fieldInfo struct {
+	index int
+	kind  fieldKind
+}
+
+// fieldKindOf classifies a struct field type. Returns 0 for unsupported types,
+// which are skipped like before.
+func fieldKindOf(typ reflect.Type) fieldKind {
+	if typ == pluralTextType {
+		return kindPlural
+	}
+	if typ.Implements(valuerType) && reflect.PointerTo(typ).Implements(scannerType) {
+		return kindNullable
+	}
+	switch typ.Kind() {
+	case reflect.String:
+		return kindString
+	case reflect.Ptr:
+		if typ.Elem().Kind() == reflect.String {
+			return kindStringPtr
+		}
+	case reflect.Slice:
+		if typ.Elem().Kind() == reflect.String {
+			return kindStringSlice
+		}
+	}
+	return 0
+}
+
+// readField returns the stored representation of f and whether it holds a
+// translation at all. Nil pointers, nil slices and null wrappers produce no
+// row, so a save leaves whatever is stored for them untouched.
+func readField(f reflect.Value, kind fieldKind) (string, bool, error) {
+	switch kind {
+	case kindString:
+		return f.String(), true, nil
+	case kindStringPtr:
+		if f.IsNil() {
+			return "", false, nil
+		}
+		return f.Elem().String(), true, nil
+	case kindStringSlice:
+		if f.IsNil() {
+			return "", false, nil
+		}
+		list := make([]string, f.Len())
+		for i := range list {
+			list[i] = f.Index(i).String()
+		}
+		b, err := json.Marshal(list)
+		if err != nil {
+			return "", false, err
+		}
+		return string(b), true, nil
+	case kindNullable:
+		dv, err := f.Interface().(driver.Valuer).Value()
+		if err != nil {
+			return "", false, err
+		}
+		switch v := dv.(type) {
+		case nil:
+			return "", false, nil
+		case string:
+			return v, true, nil
+		case []byte:
+			return string(v), true, nil
+		default:
+			return "", false, fmt.Errorf("gotrans: %s.Value returned %T, want string", f.Type(), dv)
+		}
+	}
+	return "", false, nil
+}
+
+// writeField stores value into f, the inverse of readField.
+// A list value that is not a JSON array is kept as a single element, so plain
+// text written before a field became a list still loads.
+func writeField(f reflect.Value, kind fieldKind, value string) error {
+	switch kind {
+	case kindString:
+		f.SetString(value)
+	case kindStringPtr:
+		p := reflect.New(f.Type().Elem())
+		p.Elem().SetString(value)
+		f.Set(p)
+	case kindStringSlice:
+		var list []string
+		if err := json.Unmarshal([]byte(value), &list); err != nil || list == nil {
+			list = []string{value}
+		}
+		s := reflect.MakeSlice(f.Type(), len(list), len(list))
+		for i, item := range list {
+			s.Index(i).SetString(item)
+		}
+		f.Set(s)
+	case kindNullable:
+		return f.Addr().Interface().(sql.Scanner).Scan(value)
+	}
+	return nil
+}
+
+// readPlural returns the variants of a PluralText field as (sub-field ID, value)
+// rows in a stable order. A nil map produces no rows. Categories that the
+// plural rules of l don't use are rejected.
+func readPlural(f reflect.Value, field string, l Locale) ([]Translation, error) {
+	p := f.Interface().(PluralText)
+	if len(p) == 0 {
+		return nil, nil
+	}
+	known := pluralRuleSetFor(l).categories
+	cats := make([]string, 0, len(p))
+	for c := range p {
+		if !slices.Contains(known, c) {
+			return nil, fmt.Errorf("%w %q in %s for %s", ErrUnknownPluralCategory, c, field, l)
+		}
+		cats = append(cats, string(c))
+	}
+	sort.Strings(cats)
+	rows := make([]Translation, 0, len(cats))
+	for _, c := range cats {
+		rows = append(rows, Translation{Field: PluralFieldID(field, PluralCategory(c)), Value: p[PluralCategory(c)]})
+	}
+	return rows, nil
+}
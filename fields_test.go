@@ -0,0 +1,91 @@
+package gotrans
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Listing struct {
+	ID       int
+	locale   Locale
+	Title    string
+	Subtitle *string
+	Bullets  []string
+	Note     sql.NullString
+}
+
+func (l Listing) TranslationEntityID() int        { return l.ID }
+func (l Listing) TranslationEntityLocale() Locale { return l.locale }
+func (l Listing) TranslationEntityName() string   { return "listing" }
+func (l Listing) TranslatableFields() map[string]string {
+	return map[string]string{
+		"Title":    "title",
+		"Subtitle": "subtitle",
+		"Bullets":  "bullets",
+		"Note":     "note",
+	}
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestFields_SaveOptionalAndListFields(t *testing.T) {
+	repo := &mockRepo{}
+	trans := NewTranslator[Listing](repo)
+
+	err := trans.SaveTranslations(context.Background(), []Listing{
+		{ID: 1, locale: LocaleEN, Title: "T", Subtitle: strPtr(""), Bullets: []string{"a", "b \"c\""}, Note: sql.NullString{String: "n", Valid: true}},
+		{ID: 2, locale: LocaleEN, Title: "T2"}, // nil pointer, nil slice, invalid NullString → no rows
+	})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []Translation{
+		{Entity: "listing", EntityID: 1, Field: "title", Locale: LocaleEN, Value: "T"},
+		{Entity: "listing", EntityID: 1, Field: "subtitle", Locale: LocaleEN, Value: ""},
+		{Entity: "listing", EntityID: 1, Field: "bullets", Locale: LocaleEN, Value: `["a","b \"c\""]`},
+		{Entity: "listing", EntityID: 1, Field: "note", Locale: LocaleEN, Value: "n"},
+		{Entity: "listing", EntityID: 2, Field: "title", Locale: LocaleEN, Value: "T2"},
+	}, repo.saved)
+}
+
+func TestFields_LoadOptionalAndListFields(t *testing.T) {
+	repo := &mockRepo{
+		translations: []Translation{
+			{Entity: "listing", EntityID: 1, Field: "subtitle", Locale: LocaleEN, Value: ""},
+			{Entity: "listing", EntityID: 1, Field: "bullets", Locale: LocaleEN, Value: `["a","b"]`},
+			{Entity: "listing", EntityID: 1, Field: "note", Locale: LocaleEN, Value: "n"},
+			{Entity: "listing", EntityID: 2, Field: "bullets", Locale: LocaleEN, Value: "plain text"},
+		},
+	}
+	trans := NewTranslator[Listing](repo)
+
+	listings, err := trans.LoadTranslations(context.Background(), []Listing{
+		{ID: 1, locale: LocaleEN},
+		{ID: 2, locale: LocaleEN},
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, listings[0].Subtitle, "stored empty string is a translation, not nil")
+	require.Equal(t, "", *listings[0].Subtitle)
+	require.Equal(t, []string{"a", "b"}, listings[0].Bullets)
+	require.Equal(t, sql.NullString{String: "n", Valid: true}, listings[0].Note)
+
+	require.Nil(t, listings[1].Subtitle, "no row leaves the pointer nil")
+	require.False(t, listings[1].Note.Valid)
+	require.Equal(t, []string{"plain text"}, listings[1].Bullets, "non-JSON value loads as a single item")
+}
+
+func TestFields_RoundTrip(t *testing.T) {
+	repo := &mockRepo{}
+	trans := NewTranslator[Listing](repo)
+	ctx := context.Background()
+
+	in := Listing{ID: 1, locale: LocaleFR, Title: "Titre", Subtitle: strPtr("Sous-titre"), Bullets: []string{}, Note: sql.NullString{String: "", Valid: true}}
+	require.NoError(t, trans.SaveTranslations(ctx, []Listing{in}))
+	repo.translations = repo.saved
+
+	out, err := trans.LoadTranslations(ctx, []Listing{{ID: 1, locale: LocaleFR}})
+	require.NoError(t, err)
+	require.Equal(t, in, out[0])
+}
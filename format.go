@@ -0,0 +1,247 @@
+package gotrans
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// numberSymbols are the CLDR number conventions of a language, Latin digits only.
+type numberSymbols struct {
+	decimal     string
+	group       string
+	minGrouping int    // integer digits below which no grouping separator is used, e.g. es "1000" but "10.000"
+	percent     string // pattern with "#" for the number
+}
+
+var (
+	symbolsDotComma   = numberSymbols{".", ",", 4, "#%"}
+	symbolsCommaDot   = numberSymbols{",", ".", 4, "#%"}
+	symbolsCommaSpace = numberSymbols{",", "\u00a0", 4, "#\u00a0%"}
+)
+
+// numberFormats maps a language code or tag to its number conventions.
+// Regional variants without an entry use their parent's; unlisted languages
+// use symbolsDotComma.
+var numberFormats = map[string]numberSymbols{
+	"ar": symbolsDotComma,
+	"az": symbolsCommaDot,
+	"bg": {",", "\u00a0", 4, "#%"},
+	"bs": {",", ".", 4, "#\u00a0%"},
+	"cs": symbolsCommaSpace,
+	"da": {",", ".", 4, "#\u00a0%"},
+	"de": {",", ".", 4, "#\u00a0%"},
+	"el": symbolsCommaDot,
+	"en": symbolsDotComma,
+	"es": {",", ".", 5, "#\u00a0%"},
+	"et": {",", "\u00a0", 5, "#%"},
+	"fi": symbolsCommaSpace,
+	"fr": {",", "\u202f", 4, "#\u202f%"},
+	"he": symbolsDotComma,
+	"hr": {",", ".", 4, "#\u00a0%"},
+	"hu": symbolsCommaSpace,
+	"id": symbolsCommaDot,
+	"it": symbolsCommaDot,
+	"ja": symbolsDotComma,
+	"ka": symbolsCommaSpace,
+	"kk": symbolsCommaSpace,
+	"ko": symbolsDotComma,
+	"lt": symbolsCommaSpace,
+	"lv": symbolsCommaSpace,
+	"mk": symbolsCommaDot,
+	"nl": symbolsCommaDot,
+	"no": symbolsCommaSpace,
+	"pl": {",", "\u00a0", 5, "#%"},
+	"pt": symbolsCommaDot,
+	"ro": {",", ".", 4, "#\u00a0%"},
+	"ru": symbolsCommaSpace,
+	"sk": symbolsCommaSpace,
+	"sl": {",", ".", 4, "#\u00a0%"},
+	"sq": symbolsCommaSpace,
+	"sr": symbolsCommaDot,
+	"sv": symbolsCommaSpace,
+	"th": symbolsDotComma,
+	"tr": {",", ".", 4, "%#"},
+	"uk": symbolsCommaSpace,
+	"vi": symbolsCommaDot,
+	"zh": symbolsDotComma,
+
+	"pt-PT": {",", "\u00a0", 5, "#%"},
+}
+
+func numberSymbolsFor(l Locale) numberSymbols {
+	if s, ok := lookupLocale(numberFormats, l); ok {
+		return s
+	}
+	return symbolsDotComma
+}
+
+// FormatNumber formats v with the decimal and grouping separators of l and
+// at most three fraction digits, e.g. 1234.5 → "1,234.5" in English and
+// "1.234,5" in German.
+func FormatNumber(l Locale, v float64) string {
+	return formatDecimal(numberSymbolsFor(l), v, 3)
+}
+
+// FormatInteger formats v rounded to an integer with the grouping separator of l.
+func FormatInteger(l Locale, v float64) string {
+	return formatDecimal(numberSymbolsFor(l), v, 0)
+}
+
+// FormatPercent formats v as a percentage (0.25 → "25%") following l.
+func FormatPercent(l Locale, v float64) string {
+	s := numberSymbolsFor(l)
+	return strings.Replace(s.percent, "#", formatDecimal(s, v*100, 0), 1)
+}
+
+func formatDecimal(s numberSymbols, v float64, maxFraction int) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	str := strconv.FormatFloat(math.Abs(v), 'f', maxFraction, 64)
+	intPart, frac, _ := strings.Cut(str, ".")
+	frac = strings.TrimRight(frac, "0")
+
+	var b strings.Builder
+	if v < 0 && (intPart != "0" || frac != "") {
+		b.WriteByte('-')
+	}
+	if len(intPart) >= s.minGrouping {
+		for i, d := range intPart {
+			if i > 0 && (len(intPart)-i)%3 == 0 {
+				b.WriteString(s.group)
+			}
+			b.WriteRune(d)
+		}
+	} else {
+		b.WriteString(intPart)
+	}
+	if frac != "" {
+		b.WriteString(s.decimal)
+		b.WriteString(frac)
+	}
+	return b.String()
+}
+
+// DateStyle selects a date or time format length, as in ICU.
+type DateStyle string
+
+const (
+	DateShort  DateStyle = "short"
+	DateMedium DateStyle = "medium"
+	DateLong   DateStyle = "long"
+	DateFull   DateStyle = "full"
+)
+
+// dateFormats maps a language code or tag to its CLDR short numeric date
+// pattern: d/dd day, M/MM month, yy/y year. Regional variants without an
+// entry use their parent's; unlisted languages use ISO 8601.
+var dateFormats = map[string]string{
+	"ar": "d/M/yy",
+	"az": "dd.MM.yy",
+	"bg": "d.MM.yy",
+	"bs": "d. M. yy.",
+	"cs": "dd.MM.yy",
+	"da": "dd.MM.y",
+	"de": "dd.MM.yy",
+	"el": "d/M/yy",
+	"en": "M/d/yy",
+	"es": "d/M/yy",
+	"et": "dd.MM.yy",
+	"fi": "d.M.y",
+	"fr": "dd/MM/y",
+	"he": "d.M.y",
+	"hr": "dd. MM. y.",
+	"hu": "y. MM. dd.",
+	"id": "dd/MM/yy",
+	"it": "dd/MM/yy",
+	"ja": "y/MM/dd",
+	"ka": "dd.MM.yy",
+	"kk": "dd.MM.yy",
+	"ko": "yy. M. d.",
+	"lt": "y-MM-dd",
+	"lv": "dd.MM.yy",
+	"mk": "d.M.yy",
+	"nl": "dd-MM-y",
+	"no": "dd.MM.y",
+	"pl": "d.MM.y",
+	"pt": "dd/MM/y",
+	"ro": "dd.MM.y",
+	"ru": "dd.MM.y",
+	"sk": "d. M. y",
+	"sl": "d. M. yy",
+	"sq": "d.M.yy",
+	"sr": "d.M.yy.",
+	"sv": "y-MM-dd",
+	"th": "d/M/yy",
+	"tr": "d.MM.y",
+	"uk": "dd.MM.yy",
+	"vi": "dd/MM/y",
+	"zh": "y/M/d",
+
+	"en-GB": "dd/MM/y",
+}
+
+// FormatDate formats the date part of t in the numeric order and separators
+// of l. DateShort may use a two-digit year; other styles, including the
+// default "", print the full year. Month names are not localized, so long
+// and full render like medium.
+func FormatDate(l Locale, t time.Time, style DateStyle) string {
+	pattern, ok := lookupLocale(dateFormats, l)
+	if !ok {
+		pattern = "y-MM-dd"
+	}
+	fullYear := style != DateShort
+	var b strings.Builder
+	for i := 0; i < len(pattern); {
+		c := pattern[i]
+		n := 1
+		for i+n < len(pattern) && pattern[i+n] == c {
+			n++
+		}
+		i += n
+		switch c {
+		case 'd':
+			b.WriteString(pad(t.Day(), n))
+		case 'M':
+			b.WriteString(pad(int(t.Month()), n))
+		case 'y':
+			if n == 2 && !fullYear {
+				b.WriteString(pad(t.Year()%100, 2))
+			} else {
+				b.WriteString(strconv.Itoa(t.Year()))
+			}
+		default:
+			b.WriteString(strings.Repeat(string(c), n))
+		}
+	}
+	return b.String()
+}
+
+// twelveHour lists languages whose CLDR default time format uses AM/PM.
+var twelveHour = map[string]bool{"en": true, "en-GB": false}
+
+// FormatTime formats the time of day of t following l: "3:04 PM" in English,
+// "15:04" elsewhere. Styles other than DateShort include seconds.
+func FormatTime(l Locale, t time.Time, style DateStyle) string {
+	seconds := style != DateShort
+	if h12, _ := lookupLocale(twelveHour, l); h12 {
+		if seconds {
+			return t.Format("3:04:05 PM")
+		}
+		return t.Format("3:04 PM")
+	}
+	if seconds {
+		return t.Format("15:04:05")
+	}
+	return t.Format("15:04")
+}
+
+func pad(v, width int) string {
+	s := strconv.Itoa(v)
+	for len(s) < width {
+		s = "0" + s
+	}
+	return s
+}
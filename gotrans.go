@@ -3,13 +3,22 @@ package gotrans
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
+	"slices"
+	"sort"
 	"time"
 )
 
 // ErrEmptyEntityName is returned when an entity name is empty.
 var ErrEmptyEntityName = errors.New("entity name cannot be empty")
 
+// ErrUnknownField is returned when a field mask names a DB field ID that T doesn't map.
+var ErrUnknownField = errors.New("unknown translatable field")
+
+// ErrUnknownLocale is returned when a stored or supplied locale code doesn't map to a Locale.
+var ErrUnknownLocale = errors.New("unknown locale")
+
 // Translatable is the interface every translatable entity must implement.
 // TranslatableFields returns a map: struct field name → translation field ID in DB.
 // Example: map[string]string{"Title": "title", "Description": "desc"}
@@ -30,6 +39,44 @@ type TranslatorOptions[T Translatable] struct {
 	// If set to a positive value, contexts without a deadline will be wrapped with this timeout.
 	// Zero means no default timeout is applied.
 	DefaultContextTimeout time.Duration
+
+	// EmptyValues controls how SaveTranslations treats empty field values.
+	// The zero value, EmptyValueWrite, stores them like any other value.
+	EmptyValues EmptyValuePolicy
+
+	// MissingFields controls what LoadTranslations does with mapped fields that
+	// have no stored translation. The zero value, MissingFieldKeep, leaves them as they are.
+	MissingFields MissingFieldPolicy
+
+	// MissingPlaceholderFormat is the fmt format used by MissingFieldPlaceholder,
+	// applied to the DB field ID. Defaults to DefaultMissingPlaceholderFormat.
+	MissingPlaceholderFormat string
+
+	// FallbackLocales are tried in order for fields that have no translation in
+	// the entity's own locale. LoadTranslationsWithReport records which locale
+	// each value came from.
+	FallbackLocales []Locale
+
+	// ContextLocale makes LoadTranslations treat entities whose locale is
+	// LocaleNone as having the locale stored in the context by WithLocale or
+	// LocaleMiddleware. The entities themselves are not modified.
+	ContextLocale bool
+
+	// SourceLocale is the locale other locales are translated from, e.g.
+	// LocaleEN. When set, SaveTranslations stamps every translation with the
+	// SourceHash of the source value it was based on, so that translations
+	// whose source changed since can be found as stale.
+	SourceLocale Locale
+
+	// ReportStale makes LoadTranslationsWithReport set FieldProvenance.Stale,
+	// at the cost of one more repository read per load. Needs SourceLocale.
+	ReportStale bool
+
+	// Validators run in SaveTranslations, in order, before any repository
+	// call. All of them run; their violations are combined into a single
+	// *ValidationError. Any other error aborts the save immediately.
+	// See RuleValidator, MessageFormatValidator and PlaceholderValidator.
+	Validators []TranslationValidator
 }
 
 // Translator is the main interface for translation operations.
@@ -37,31 +84,62 @@ type TranslatorOptions[T Translatable] struct {
 // passed to the delete methods — the translator already knows it.
 type Translator[T Translatable] interface {
 	LoadTranslations(ctx context.Context, entities []T) ([]T, error)
+	// LoadTranslationsWithReport is LoadTranslations that also reports, per
+	// entity and per field, where each value came from and which mapped fields
+	// had no stored translation.
+	LoadTranslationsWithReport(ctx context.Context, entities []T) ([]T, LoadReport, error)
 	SaveTranslations(ctx context.Context, entities []T) error
+	// SaveTranslationsWithOptions is SaveTranslations with per-call options,
+	// e.g. a field mask so that only the fields the caller edited are written.
+	SaveTranslationsWithOptions(ctx context.Context, entities []T, opts SaveOptions) error
 	// DeleteTranslations removes translations for specific entity IDs, locale and fields.
 	DeleteTranslations(ctx context.Context, locale Locale, entityIDs []int, fields []string) error
 	// DeleteTranslationsByEntity removes all translations for the given entity IDs across all locales.
 	DeleteTranslationsByEntity(ctx context.Context, entityIDs []int) error
+	// RevertTranslation restores the translation of rev to its value right
+	// after rev, through the save path: validators run, the EmptyValues
+	// policy applies, and a history repository records the revert. The
+	// stored translation keeps its workflow status; one that no longer
+	// exists is restored as StatusPublished, like a default save.
+	RevertTranslation(ctx context.Context, rev Revision) error
+	// TransitionTranslations moves the stored translations of entityIDs in
+	// locale to status to, for the given fields or all stored fields. Every
+	// transition is checked first; if one isn't allowed nothing changes.
+	// The write-back is conditional on the versions read, so a concurrent
+	// save makes it fail with a *VersionConflictError.
+	TransitionTranslations(ctx context.Context, locale Locale, entityIDs []int, fields []string, to TranslationStatus) error
 }
 
 var _ Translator[Translatable] = (*translator[Translatable])(nil)
 
 type translator[T Translatable] struct {
-	repo               TranslationRepository
-	entityName         string         // derived from T once at construction, never changes
-	fieldIndex         map[string]int // DB field ID → struct field index, pre-built once
-	defaultCtxTimeout  time.Duration
+	repo              TranslationRepository
+	entityName        string               // derived from T once at construction, never changes
+	fieldIndex        map[string]fieldInfo // DB field ID → struct field index and kind, pre-built once
+	fieldIDs          []string             // DB field IDs in stable order, used for extraction
+	hasGetter         bool                 // *T implements TranslationFieldGetter, extraction skips reflection
+	hasSetter         bool                 // *T implements TranslationFieldSetter, loading skips reflection
+	hasPlural         bool                 // some field is a PluralText, which accessors don't carry
+	hasVersions       bool                 // *T implements TranslationVersioned
+	isPtr             bool                 // T is a pointer type; elements are mutated in place
+	defaultCtxTimeout time.Duration
+	emptyValues       EmptyValuePolicy
+	missingFields     MissingFieldPolicy
+	placeholderFormat string
+	fallbackLocales   []Locale
+	contextLocale     bool
+	sourceLocale      Locale
+	reportStale       bool
+	validators        []TranslationValidator
 }
 
 // NewTranslator creates a translator for entity type T.
 // The entity name and field index are resolved once from a zero value of T.
+// T may be a struct type (Product) or a pointer to one (*Product); with a
+// pointer type the pointed-to structs are updated in place and nil elements
+// are skipped.
 func NewTranslator[T Translatable](repo TranslationRepository) Translator[T] {
-	var zero T
-	return &translator[T]{
-		repo:       repo,
-		entityName: zero.TranslationEntityName(),
-		fieldIndex: buildFieldIndex[T](),
-	}
+	return NewTranslatorWithOptions(TranslatorOptions[T]{Repository: repo})
 }
 
 // NewTranslatorWithOptions creates a translator with advanced options including default context timeout.
@@ -72,25 +150,56 @@ func NewTranslator[T Translatable](repo TranslationRepository) Translator[T] {
 //		DefaultContextTimeout: 30 * time.Second,
 //	})
 func NewTranslatorWithOptions[T Translatable](opts TranslatorOptions[T]) Translator[T] {
-	var zero T
+	zero := newEntity[T]()
+	isPtr := reflect.TypeOf(&zero).Elem().Kind() == reflect.Ptr
+	target := any(&zero)
+	if isPtr {
+		target = any(zero)
+	}
+	_, hasGetter := target.(TranslationFieldGetter)
+	_, hasSetter := target.(TranslationFieldSetter)
+	_, hasVersions := target.(TranslationVersioned)
+	fieldIndex := buildFieldIndex(zero)
+	hasPlural := false
+	for _, fi := range fieldIndex {
+		hasPlural = hasPlural || fi.kind == kindPlural
+	}
+	placeholderFormat := opts.MissingPlaceholderFormat
+	if placeholderFormat == "" {
+		placeholderFormat = DefaultMissingPlaceholderFormat
+	}
 	return &translator[T]{
 		repo:              opts.Repository,
 		entityName:        zero.TranslationEntityName(),
-		fieldIndex:        buildFieldIndex[T](),
+		fieldIndex:        fieldIndex,
+		fieldIDs:          sortedKeys(fieldIndex),
+		hasGetter:         hasGetter,
+		hasSetter:         hasSetter,
+		hasPlural:         hasPlural,
+		hasVersions:       hasVersions,
+		isPtr:             isPtr,
 		defaultCtxTimeout: opts.DefaultContextTimeout,
+		emptyValues:       opts.EmptyValues,
+		missingFields:     opts.MissingFields,
+		placeholderFormat: placeholderFormat,
+		fallbackLocales:   opts.FallbackLocales,
+		contextLocale:     opts.ContextLocale,
+		sourceLocale:      opts.SourceLocale,
+		reportStale:       opts.ReportStale && opts.SourceLocale != LocaleNone,
+		validators:        opts.Validators,
 	}
 }
 
 // contextWithDefault applies default timeout if context has no deadline.
-func (t *translator[T]) contextWithDefault(ctx context.Context) context.Context {
+// The returned cancel function must always be called.
+func (t *translator[T]) contextWithDefault(ctx context.Context) (context.Context, context.CancelFunc) {
 	if t.defaultCtxTimeout <= 0 {
-		return ctx
+		return ctx, func() {}
 	}
 	if _, ok := ctx.Deadline(); ok {
-		return ctx // context already has a deadline
+		return ctx, func() {} // context already has a deadline
 	}
-	newCtx, _ := context.WithTimeout(ctx, t.defaultCtxTimeout)
-	return newCtx
+	return context.WithTimeout(ctx, t.defaultCtxTimeout)
 }
 
 func (t *translator[T]) DeleteTranslationsByEntity(ctx context.Context, entityIDs []int) error {
@@ -98,89 +207,185 @@ func (t *translator[T]) DeleteTranslationsByEntity(ctx context.Context, entityID
 		return nil
 	}
 	if t.entityName == "" {
-		return ErrEmptyEntityName
+		return t.wrap(opDeleteByEntity, LocaleNone, entityIDs, ErrEmptyEntityName)
 	}
-	return t.repo.MassDelete(ctx, LocaleNone, t.entityName, entityIDs, nil)
+	return t.wrap(opDeleteByEntity, LocaleNone, entityIDs,
+		t.repo.MassDelete(ctx, LocaleNone, t.entityName, entityIDs, nil))
 }
 
 func (t *translator[T]) DeleteTranslations(ctx context.Context, locale Locale, entityIDs []int, fields []string) error {
 	if len(entityIDs) == 0 {
 		return nil
 	}
-	return t.repo.MassDelete(ctx, locale, t.entityName, entityIDs, fields)
+	return t.wrap(opDelete, locale, entityIDs,
+		t.repo.MassDelete(ctx, locale, t.entityName, entityIDs, t.storageFields(fields)))
 }
 
 func (t *translator[T]) LoadTranslations(ctx context.Context, entities []T) ([]T, error) {
+	return t.load(ctx, entities, nil)
+}
+
+func (t *translator[T]) LoadTranslationsWithReport(ctx context.Context, entities []T) ([]T, LoadReport, error) {
+	report := LoadReport{Entities: make([]EntityReport, len(entities))}
+	entities, err := t.load(ctx, entities, &report)
+	if err != nil {
+		return nil, LoadReport{}, err
+	}
+	return entities, report, nil
+}
+
+// load implements both LoadTranslations variants. report is nil when the
+// caller doesn't need one; otherwise it is filled index-aligned with entities.
+func (t *translator[T]) load(ctx context.Context, entities []T, report *LoadReport) ([]T, error) {
 	if len(entities) == 0 {
 		return entities, nil
 	}
 
 	// Apply default context timeout if needed
-	ctx = t.contextWithDefault(ctx)
+	ctx, cancel := t.contextWithDefault(ctx)
+	defer cancel()
 
 	// Check if context is already done
 	if err := ctx.Err(); err != nil {
-		return nil, err
+		return nil, t.wrap(opLoad, LocaleNone, nil, err)
 	}
 
 	if t.entityName == "" {
-		return nil, ErrEmptyEntityName
+		return nil, t.wrap(opLoad, LocaleNone, nil, ErrEmptyEntityName)
 	}
 
-	// Group entity IDs by locale, deduplicating to avoid redundant DB queries.
-	type localeID struct {
-		locale Locale
-		id     int
+	var hits *cacheHitRecorder
+	if report != nil {
+		ctx, hits = withCacheHitRecorder(ctx)
 	}
-	seen := make(map[localeID]struct{}, len(entities))
+
+	entityLocale := t.entityLocaleFunc(ctx)
+
+	// Group entity IDs by locale, deduplicating to avoid redundant DB queries.
+	fetched := make(map[translationKey]struct{}, len(entities))
 	localeMap := make(map[Locale][]int)
+	chains := make(map[Locale][]Locale)
 	for _, e := range entities {
-		k := localeID{e.TranslationEntityLocale(), e.TranslationEntityID()}
-		if _, dup := seen[k]; !dup {
-			seen[k] = struct{}{}
-			locale := e.TranslationEntityLocale()
-			localeMap[locale] = append(localeMap[locale], e.TranslationEntityID())
+		if t.isNil(e) {
+			continue
+		}
+		k := translationKey{e.TranslationEntityID(), entityLocale(e)}
+		if _, dup := fetched[k]; !dup {
+			fetched[k] = struct{}{}
+			localeMap[k.locale] = append(localeMap[k.locale], k.id)
+		}
+		if _, ok := chains[k.locale]; !ok {
+			chains[k.locale] = t.localeChain(k.locale)
 		}
 	}
 
-	// Fetch translations for each locale group.
-	var allTranslations []Translation
-	for locale, ids := range localeMap {
+	// Build (entityID, locale) → []Translation lookup for O(1) access per entity.
+	state := loadState{
+		lookup: make(map[translationKey][]Translation, len(entities)),
+		hits:   hits,
+		track:  report != nil || t.missingFields != MissingFieldKeep,
+		report: report != nil,
+	}
+	preview := PreviewFromContext(ctx)
+	fetch := func(locale Locale, ids []int) error {
 		trs, err := t.repo.GetTranslations(ctx, locale, t.entityName, ids)
 		if err != nil {
+			return t.wrap(opLoad, locale, ids, err)
+		}
+		for _, tr := range trs {
+			// Unpublished values are treated as missing, so fallbacks apply.
+			if tr.Status != StatusPublished && !preview {
+				continue
+			}
+			k := translationKey{tr.EntityID, tr.Locale}
+			state.lookup[k] = append(state.lookup[k], tr)
+		}
+		return nil
+	}
+
+	// Fetch translations for each locale group.
+	for locale, ids := range localeMap {
+		if err := fetch(locale, ids); err != nil {
 			return nil, err
 		}
-		allTranslations = append(allTranslations, trs...)
 	}
 
-	if len(allTranslations) == 0 {
-		return entities, nil
+	// Walk the rest of each entity's chain (parents, then fallbacks) one
+	// position per round, batching the entities that need the same locale
+	// next. An entity drops out once every field resolves.
+	next := make(map[translationKey]int, len(fetched))
+	for k := range fetched {
+		next[k] = 1
+	}
+	for len(next) > 0 {
+		round := make(map[Locale][]int)
+		for k, i := range next {
+			chain := chains[k.locale]
+			for i < len(chain) {
+				if _, done := fetched[translationKey{k.id, chain[i]}]; !done {
+					break
+				}
+				i++
+			}
+			if i == len(chain) || t.resolvedAll(&state, k.id, chain) {
+				delete(next, k)
+				continue
+			}
+			next[k] = i + 1
+			fetched[translationKey{k.id, chain[i]}] = struct{}{}
+			round[chain[i]] = append(round[chain[i]], k.id)
+		}
+		locales := make([]Locale, 0, len(round))
+		for l := range round {
+			locales = append(locales, l)
+		}
+		sort.Slice(locales, func(i, j int) bool { return locales[i] < locales[j] })
+		for _, l := range locales {
+			ids := round[l]
+			sort.Ints(ids)
+			if err := fetch(l, ids); err != nil {
+				return nil, err
+			}
+		}
 	}
 
-	// Build (entityID, locale) → []Translation lookup for O(1) access per entity.
-	type key struct {
-		id     int
-		locale Locale
+	if len(state.lookup) == 0 && !state.track && !t.hasVersions {
+		return entities, nil
 	}
-	lookup := make(map[key][]Translation, len(entities))
-	for _, tr := range allTranslations {
-		k := key{tr.EntityID, tr.Locale}
-		lookup[k] = append(lookup[k], tr)
+
+	if report != nil && t.reportStale {
+		ids := make([]int, 0, len(fetched))
+		for k := range fetched {
+			ids = append(ids, k.id)
+		}
+		hashes, err := t.currentSourceHashes(ctx, uniqueInts(ids), nil)
+		if err != nil {
+			return nil, t.wrap(opLoad, t.sourceLocale, ids, err)
+		}
+		state.sourceHashes = hashes
 	}
 
 	// Apply translations to each entity using pre-built field index.
 	for i := range entities {
-		trs, ok := lookup[key{entities[i].TranslationEntityID(), entities[i].TranslationEntityLocale()}]
-		if !ok {
+		if t.isNil(entities[i]) {
 			continue
 		}
-		v := reflect.ValueOf(&entities[i]).Elem()
-		for _, tr := range trs {
-			if idx, ok := t.fieldIndex[tr.Field]; ok {
-				if f := v.Field(idx); f.Kind() == reflect.String && f.CanSet() {
-					f.SetString(tr.Value)
-				}
+		id, locale := entities[i].TranslationEntityID(), entityLocale(entities[i])
+		er, err := t.applyTranslations(&entities[i], id, chains[locale], &state)
+		if err != nil {
+			return nil, t.wrap(opLoad, locale, []int{id}, err)
+		}
+		if t.hasVersions {
+			// Only rows of the entity's own locale are what a save replaces.
+			versions := make(map[string]int64)
+			for _, tr := range state.lookup[translationKey{id, locale}] {
+				versions[tr.Field] = tr.Version
 			}
+			t.accessorTarget(&entities[i]).(TranslationVersioned).SetTranslationVersions(versions)
+		}
+		if report != nil {
+			er.EntityID, er.Locale = id, locale
+			report.Entities[i] = er
 		}
 	}
 
@@ -188,28 +393,107 @@ func (t *translator[T]) LoadTranslations(ctx context.Context, entities []T) ([]T
 }
 
 func (t *translator[T]) SaveTranslations(ctx context.Context, entities []T) error {
+	return t.SaveTranslationsWithOptions(ctx, entities, SaveOptions{})
+}
+
+func (t *translator[T]) SaveTranslationsWithOptions(ctx context.Context, entities []T, opts SaveOptions) error {
 	if len(entities) == 0 {
 		return nil
 	}
 
 	// Apply default context timeout if needed
-	ctx = t.contextWithDefault(ctx)
+	ctx, cancel := t.contextWithDefault(ctx)
+	defer cancel()
 
 	// Check if context is already done
 	if err := ctx.Err(); err != nil {
-		return err
+		return t.wrap(opSave, LocaleNone, nil, err)
 	}
 
 	if t.entityName == "" {
-		return ErrEmptyEntityName
+		return t.wrap(opSave, LocaleNone, nil, ErrEmptyEntityName)
+	}
+
+	mask, err := t.fieldMask(opts.Fields)
+	if err != nil {
+		return t.wrap(opSave, LocaleNone, nil, err)
+	}
+	if opts.Conditional {
+		if !t.hasVersions {
+			return t.wrap(opSave, LocaleNone, nil, fmt.Errorf("gotrans: conditional save of %s needs entities implementing TranslationVersioned", t.entityName))
+		}
+		ctx = WithVersionCheck(ctx)
 	}
 
-	// Group translations by locale for batch save.
+	// Group translations by locale for batch save. Empty values are routed
+	// according to the policy: written, dropped, or queued for deletion.
 	localeMap := make(map[Locale][]Translation)
-	for _, e := range entities {
-		trs := extractTranslations(e)
-		locale := e.TranslationEntityLocale()
-		localeMap[locale] = append(localeMap[locale], trs...)
+	var checked []Translation                    // every row in the mask, empty ones included, for validators
+	deletes := make(map[Locale]map[string][]int) // locale → field → entity IDs
+	deleteVersions := make(map[TranslationKey]int64)
+	queueDelete := func(locale Locale, id int, field string, version int64) {
+		// LocaleNone would make MassDelete remove every locale, never do that here.
+		if locale == LocaleNone {
+			return
+		}
+		if deletes[locale] == nil {
+			deletes[locale] = make(map[string][]int)
+		}
+		deletes[locale][field] = append(deletes[locale][field], id)
+		deleteVersions[TranslationKey{Entity: t.entityName, EntityID: id, Field: field, Locale: locale}] = version
+	}
+	var trs []Translation // reused across entities, rows are copied out below
+	for i := range entities {
+		if t.isNil(entities[i]) {
+			continue
+		}
+		locale := entities[i].TranslationEntityLocale()
+		trs, err = t.extractTranslations(trs[:0], &entities[i])
+		if err != nil {
+			return t.wrap(opSave, locale, []int{entities[i].TranslationEntityID()}, err)
+		}
+		var versions map[string]int64
+		if opts.Conditional {
+			versions = t.accessorTarget(&entities[i]).(TranslationVersioned).TranslationVersions()
+		}
+		for _, tr := range trs {
+			if mask != nil {
+				if _, ok := mask[t.baseField(tr.Field)]; !ok {
+					continue
+				}
+			}
+			tr.Status = opts.Status
+			tr.Version = versions[tr.Field]
+			if len(t.validators) > 0 {
+				checked = append(checked, tr)
+			}
+			if tr.Value == "" && t.emptyValues != EmptyValueWrite {
+				if t.emptyValues == EmptyValueDelete {
+					queueDelete(locale, tr.EntityID, tr.Field, tr.Version)
+				}
+				continue
+			}
+			localeMap[locale] = append(localeMap[locale], tr)
+		}
+		for _, field := range t.removedVariants(trs, locale, mask) {
+			queueDelete(locale, entities[i].TranslationEntityID(), field, versions[field])
+		}
+	}
+
+	if err := t.validate(ctx, checked); err != nil {
+		return t.wrap(opSave, LocaleNone, nil, err)
+	}
+
+	if t.sourceLocale != LocaleNone {
+		if err := t.stampSourceHashes(ctx, localeMap); err != nil {
+			return t.wrap(opSave, t.sourceLocale, nil, err)
+		}
+	}
+
+	if opts.Conditional {
+		if err := t.checkVersions(ctx, localeMap, deleteVersions); err != nil {
+			return t.wrap(opSave, LocaleNone, nil, err)
+		}
 	}
 
 	for locale, trs := range localeMap {
@@ -217,21 +501,556 @@ func (t *translator[T]) SaveTranslations(ctx context.Context, entities []T) erro
 			continue
 		}
 		if err := t.repo.MassCreateOrUpdate(ctx, locale, trs); err != nil {
+			return t.wrap(opSave, locale, entityIDsOf(trs), err)
+		}
+	}
+
+	for locale, byField := range deletes {
+		for field, ids := range byField {
+			deleteCtx := ctx
+			if opts.Conditional {
+				versions := make(map[TranslationKey]int64, len(ids))
+				for _, id := range ids {
+					key := TranslationKey{Entity: t.entityName, EntityID: id, Field: field, Locale: locale}
+					versions[key] = deleteVersions[key]
+				}
+				deleteCtx = WithDeleteVersions(ctx, versions)
+			}
+			if err := t.repo.MassDelete(deleteCtx, locale, t.entityName, ids, []string{field}); err != nil {
+				return t.wrap(opSave, locale, ids, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (t *translator[T]) RevertTranslation(ctx context.Context, rev Revision) error {
+	ctx, cancel := t.contextWithDefault(ctx)
+	defer cancel()
+
+	ids := []int{rev.EntityID}
+	if err := ctx.Err(); err != nil {
+		return t.wrap(opRevert, rev.Locale, ids, err)
+	}
+	if _, ok := t.fieldIndex[t.baseField(rev.Field)]; !ok || rev.Entity != t.entityName || rev.Locale == LocaleNone {
+		return t.wrap(opRevert, rev.Locale, ids, fmt.Errorf("%w: %s %d %s %s", ErrRevisionMismatch, rev.Entity, rev.EntityID, rev.Field, rev.Locale))
+	}
+
+	tr := Translation{Entity: rev.Entity, EntityID: rev.EntityID, Field: rev.Field, Locale: rev.Locale, Value: rev.NewValue}
+	if rev.Kind == RevisionDelete {
+		return t.wrap(opRevert, rev.Locale, ids, t.repo.MassDelete(ctx, rev.Locale, t.entityName, ids, []string{rev.Field}))
+	}
+	if err := t.validate(ctx, []Translation{tr}); err != nil {
+		return t.wrap(opRevert, rev.Locale, ids, err)
+	}
+
+	var err error
+	switch {
+	case tr.Value == "" && t.emptyValues == EmptyValueDelete:
+		err = t.repo.MassDelete(ctx, rev.Locale, t.entityName, ids, []string{rev.Field})
+	case tr.Value == "" && t.emptyValues == EmptyValueSkip:
+	default:
+		var stored []Translation
+		if stored, err = t.repo.GetTranslations(ctx, rev.Locale, t.entityName, ids); err != nil {
+			break
+		}
+		for _, cur := range stored {
+			if cur.Field == tr.Field && cur.Locale == tr.Locale {
+				tr.Status = cur.Status
+			}
+		}
+		trs := []Translation{tr}
+		if t.sourceLocale != LocaleNone {
+			if err = t.stampSourceHashes(ctx, map[Locale][]Translation{rev.Locale: trs}); err != nil {
+				break
+			}
+		}
+		err = t.repo.MassCreateOrUpdate(ctx, rev.Locale, trs)
+	}
+	return t.wrap(opRevert, rev.Locale, ids, err)
+}
+
+func (t *translator[T]) TransitionTranslations(ctx context.Context, locale Locale, entityIDs []int, fields []string, to TranslationStatus) error {
+	if len(entityIDs) == 0 {
+		return nil
+	}
+	ctx, cancel := t.contextWithDefault(ctx)
+	defer cancel()
+
+	if err := ctx.Err(); err != nil {
+		return t.wrap(opTransition, locale, entityIDs, err)
+	}
+	if locale == LocaleNone {
+		return t.wrap(opTransition, locale, entityIDs, fmt.Errorf("%w: no locale", ErrInvalidTransition))
+	}
+	mask, err := t.fieldMask(fields)
+	if err != nil {
+		return t.wrap(opTransition, locale, entityIDs, err)
+	}
+
+	trs, err := t.repo.GetTranslations(ctx, locale, t.entityName, entityIDs)
+	if err != nil {
+		return t.wrap(opTransition, locale, entityIDs, err)
+	}
+
+	// MassCreateOrUpdate replaces exactly the (ID, field) pairs of its batch,
+	// so writing the scope back with the new status changes nothing else.
+	found := make(map[fieldKey]struct{})
+	scope := make([]Translation, 0, len(trs))
+	for _, tr := range trs {
+		base := t.baseField(tr.Field)
+		if mask != nil {
+			if _, ok := mask[base]; !ok {
+				continue
+			}
+		}
+		if !tr.Status.CanTransitionTo(to) {
+			return t.wrap(opTransition, locale, []int{tr.EntityID},
+				fmt.Errorf("%w: %s %d %s from %s to %s", ErrInvalidTransition, t.entityName, tr.EntityID, tr.Field, tr.Status, to))
+		}
+		found[fieldKey{tr.EntityID, base}] = struct{}{}
+		found[fieldKey{tr.EntityID, ""}] = struct{}{}
+		tr.Status = to
+		scope = append(scope, tr)
+	}
+	for _, id := range entityIDs {
+		if mask == nil {
+			if _, ok := found[fieldKey{id, ""}]; !ok {
+				return t.wrap(opTransition, locale, []int{id}, fmt.Errorf("%w: %s %d", ErrTranslationNotFound, t.entityName, id))
+			}
+			continue
+		}
+		for _, f := range fields {
+			if _, ok := found[fieldKey{id, f}]; !ok {
+				return t.wrap(opTransition, locale, []int{id}, fmt.Errorf("%w: %s %d %s", ErrTranslationNotFound, t.entityName, id, f))
+			}
+		}
+	}
+
+	// The rows carry the versions just read: a save that lands in between
+	// fails the write-back with a conflict instead of being undone.
+	return t.wrap(opTransition, locale, entityIDs, t.repo.MassCreateOrUpdate(WithVersionCheck(ctx), locale, scope))
+}
+
+// Operation names recorded in *Error.
+const (
+	opLoad           = "LoadTranslations"
+	opSave           = "SaveTranslations"
+	opDelete         = "DeleteTranslations"
+	opDeleteByEntity = "DeleteTranslationsByEntity"
+	opRevert         = "RevertTranslation"
+	opTransition     = "TransitionTranslations"
+)
+
+// wrap returns err as an *Error for this translator's entity, keeping an
+// *Error from the repository intact.
+// checkVersions compares the versions a conditional save expects, for the
+// rows it writes and the keys it deletes, with the stored ones before anything
+// is written. Every mismatch is returned in one *VersionConflictError. The
+// repository checks each batch again, which catches changes made after this.
+func (t *translator[T]) checkVersions(ctx context.Context, writes map[Locale][]Translation, deletes map[TranslationKey]int64) error {
+	expected := make(map[TranslationKey]int64, len(deletes))
+	ids := make(map[Locale][]int)
+	for key, version := range deletes {
+		expected[key] = version
+		ids[key.Locale] = append(ids[key.Locale], key.EntityID)
+	}
+	for locale, trs := range writes {
+		for _, tr := range trs {
+			expected[tr.Key()] = tr.Version
+			ids[locale] = append(ids[locale], tr.EntityID)
+		}
+	}
+
+	stored := make(map[TranslationKey]int64, len(expected))
+	for locale, entityIDs := range ids {
+		trs, err := t.repo.GetTranslations(ctx, locale, t.entityName, uniqueInts(entityIDs))
+		if err != nil {
 			return err
 		}
+		for _, tr := range trs {
+			stored[tr.Key()] = tr.Version
+		}
+	}
+
+	var conflicts []VersionConflict
+	for key, version := range expected {
+		if actual := stored[key]; actual != version {
+			conflicts = append(conflicts, VersionConflict{TranslationKey: key, Expected: version, Actual: actual})
+		}
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+	sort.Slice(conflicts, func(i, j int) bool { return revisionKeyLess(conflicts[i].TranslationKey, conflicts[j].TranslationKey) })
+	return &VersionConflictError{Conflicts: conflicts}
+}
+
+func (t *translator[T]) wrap(op string, locale Locale, ids []int, err error) error {
+	return wrapError(op, t.entityName, locale, ids, err)
+}
+
+// entityIDsOf returns the distinct entity IDs of trs in order of appearance.
+func entityIDsOf(trs []Translation) []int {
+	ids := make([]int, 0, len(trs))
+	for _, tr := range trs {
+		ids = append(ids, tr.EntityID)
 	}
+	return uniqueInts(ids)
+}
 
+// validate runs every configured validator over trs and combines their
+// violations. An error that is not a *ValidationError is returned as is.
+func (t *translator[T]) validate(ctx context.Context, trs []Translation) error {
+	var combined ValidationError
+	for _, v := range t.validators {
+		err := v.ValidateTranslations(ctx, t.repo, trs)
+		if err == nil {
+			continue
+		}
+		var ve *ValidationError
+		if !errors.As(err, &ve) {
+			return err
+		}
+		combined.Violations = append(combined.Violations, ve.Violations...)
+	}
+	if len(combined.Violations) > 0 {
+		return &combined
+	}
 	return nil
 }
 
+// fieldMask validates a per-call field mask and returns it as a set.
+// A nil set means "all fields".
+func (t *translator[T]) fieldMask(fields []string) (map[string]struct{}, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	mask := make(map[string]struct{}, len(fields))
+	for _, f := range fields {
+		if _, ok := t.fieldIndex[f]; !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownField, f)
+		}
+		mask[f] = struct{}{}
+	}
+	return mask, nil
+}
+
+// baseField returns the mapped DB field ID a stored field belongs to:
+// "title#few" → "title" when title is a PluralText field.
+func (t *translator[T]) baseField(field string) string {
+	if base, _, ok := splitPluralFieldID(field); ok && t.fieldIndex[base].kind == kindPlural {
+		return base
+	}
+	return field
+}
+
+// removedVariants returns the storage field IDs of the plural variants that
+// the PluralText fields in trs, saved in locale, no longer have. A field
+// without any variant is not being saved and keeps its rows.
+func (t *translator[T]) removedVariants(trs []Translation, locale Locale, mask map[string]struct{}) []string {
+	present := make(map[string]struct{}, len(trs))
+	var saved []string
+	for _, tr := range trs {
+		base := t.baseField(tr.Field)
+		if base == tr.Field {
+			continue
+		}
+		if _, ok := mask[base]; mask != nil && !ok {
+			continue
+		}
+		if _, ok := present[base]; !ok {
+			saved = append(saved, base)
+		}
+		present[base] = struct{}{}
+		present[tr.Field] = struct{}{}
+	}
+	var removed []string
+	for _, base := range saved {
+		for _, c := range pluralRuleSetFor(locale).categories {
+			if _, ok := present[PluralFieldID(base, c)]; !ok {
+				removed = append(removed, PluralFieldID(base, c))
+			}
+		}
+	}
+	return removed
+}
+
+// storageFields expands PluralText field IDs into the sub-field IDs of every
+// plural category, so deleting "title" also deletes "title#one", "title#few", ...
+func (t *translator[T]) storageFields(fields []string) []string {
+	var out []string
+	for i, f := range fields {
+		if t.fieldIndex[f].kind != kindPlural {
+			if out != nil {
+				out = append(out, f)
+			}
+			continue
+		}
+		if out == nil {
+			out = append(make([]string, 0, len(fields)+len(categoriesAll)), fields[:i]...)
+		}
+		for _, c := range categoriesAll {
+			out = append(out, PluralFieldID(f, c))
+		}
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
+
 // ------------------------------------------------
 // --------------- Helpers ------------------------
 // ------------------------------------------------
 
-// buildFieldIndex builds a map from DB field ID → struct field index for type T.
-// Pre-built once at translator construction — never recalculated per request.
-func buildFieldIndex[T Translatable]() map[string]int {
+// translationKey identifies the rows of one entity in one locale.
+type translationKey struct {
+	id     int
+	locale Locale
+}
+
+// loadState carries the fetched rows and report settings through a load.
+type loadState struct {
+	lookup map[translationKey][]Translation
+	hits   *cacheHitRecorder // nil unless a report is requested
+	track  bool              // missing fields matter (policy or report)
+	report bool              // record provenance
+	// sourceHashes holds the current source hash per entity and base field
+	// when staleness is reported, nil otherwise.
+	sourceHashes map[fieldKey]string
+}
+
+// resolve returns the row for field, trying the locales of chain in order.
+func (s *loadState) resolve(id int, chain []Locale, field string) (Translation, bool) {
+	for _, locale := range chain {
+		for _, tr := range s.lookup[translationKey{id, locale}] {
+			if tr.Field == field {
+				return tr, true
+			}
+		}
+	}
+	return Translation{}, false
+}
+
+// resolvePlural collects the variants of a PluralText field from the first
+// locale of chain that has any. Variants are never mixed across locales.
+// The returned row, used for provenance, is the "other" variant if present.
+func (s *loadState) resolvePlural(id int, chain []Locale, field string) (PluralText, Translation, bool) {
+	for _, locale := range chain {
+		var p PluralText
+		var first Translation
+		for _, tr := range s.lookup[translationKey{id, locale}] {
+			base, c, ok := splitPluralFieldID(tr.Field)
+			if !ok || base != field {
+				continue
+			}
+			if p == nil {
+				p, first = make(PluralText), tr
+			}
+			if c == PluralOther {
+				first = tr
+			}
+			p[c] = tr.Value
+		}
+		if p != nil {
+			return p, first, true
+		}
+	}
+	return nil, Translation{}, false
+}
+
+// resolveField is resolve for any field kind.
+func (t *translator[T]) resolveField(s *loadState, id int, chain []Locale, field string) (Translation, bool) {
+	if t.fieldIndex[field].kind == kindPlural {
+		_, tr, ok := s.resolvePlural(id, chain, field)
+		return tr, ok
+	}
+	return s.resolve(id, chain, field)
+}
+
+// entityLocaleFunc returns the function load uses for an entity's locale:
+// TranslationEntityLocale, with LocaleNone replaced by the context locale when
+// ContextLocale is set.
+func (t *translator[T]) entityLocaleFunc(ctx context.Context) func(T) Locale {
+	ctxLocale, ok := LocaleFromContext(ctx)
+	if !t.contextLocale || !ok {
+		return T.TranslationEntityLocale
+	}
+	return func(e T) Locale {
+		if l := e.TranslationEntityLocale(); l != LocaleNone {
+			return l
+		}
+		return ctxLocale
+	}
+}
+
+// localeChain returns the locales to try for an entity: its own and its
+// parents (en-GB, then en), then each configured fallback and its parents.
+func (t *translator[T]) localeChain(locale Locale) []Locale {
+	chain := make([]Locale, 0, 2+len(t.fallbackLocales))
+	for _, l := range append([]Locale{locale}, t.fallbackLocales...) {
+		for ; l != LocaleNone; l = l.Parent() {
+			if !slices.Contains(chain, l) {
+				chain = append(chain, l)
+			}
+		}
+	}
+	if len(chain) == 0 {
+		chain = append(chain, locale)
+	}
+	return chain
+}
+
+// resolvedAll reports whether every mapped field of the entity already has a row.
+func (t *translator[T]) resolvedAll(s *loadState, id int, chain []Locale) bool {
+	for _, field := range t.fieldIDs {
+		if _, ok := t.resolveField(s, id, chain, field); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// applyTranslations resolves every mapped field of e along chain and writes
+// the value. Fields without a row are subject to the missing-field policy
+// when tracking is on. Unresolved fields and, for reports, per-field
+// provenance are returned.
+func (t *translator[T]) applyTranslations(e *T, id int, chain []Locale, s *loadState) (EntityReport, error) {
+	var setter TranslationFieldSetter
+	if t.hasSetter {
+		setter = t.accessorTarget(e).(TranslationFieldSetter)
+	}
+	// PluralText fields always go through reflection, accessors only carry
+	// strings. Without them a setter makes the reflect.Value unnecessary.
+	var v reflect.Value
+	if setter == nil || t.hasPlural {
+		v = t.structValue(e)
+	}
+
+	set := func(field, value string) error {
+		if setter != nil && t.fieldIndex[field].kind != kindPlural {
+			setter.SetTranslationFieldValue(field, value)
+			return nil
+		}
+		if f := v.Field(t.fieldIndex[field].index); f.CanSet() {
+			if t.fieldIndex[field].kind == kindPlural {
+				f.Set(reflect.ValueOf(PluralText{PluralOther: value}))
+				return nil
+			}
+			if err := writeField(f, t.fieldIndex[field].kind, value); err != nil {
+				return fmt.Errorf("gotrans: load %s.%s: %w", t.entityName, field, err)
+			}
+		}
+		return nil
+	}
+
+	var er EntityReport
+	for _, field := range t.fieldIDs {
+		var tr Translation
+		var ok bool
+		if t.fieldIndex[field].kind == kindPlural {
+			var p PluralText
+			if p, tr, ok = s.resolvePlural(id, chain, field); ok {
+				if f := v.Field(t.fieldIndex[field].index); f.CanSet() {
+					f.Set(reflect.ValueOf(p))
+				}
+			}
+		} else if tr, ok = s.resolve(id, chain, field); ok {
+			if err := set(field, tr.Value); err != nil {
+				return EntityReport{}, err
+			}
+		}
+		if ok {
+			if s.report {
+				if er.Fields == nil {
+					er.Fields = make(map[string]FieldProvenance, len(t.fieldIDs))
+				}
+				er.Fields[field] = t.provenance(tr, chain[0], s)
+			}
+			continue
+		}
+		if !s.track {
+			continue
+		}
+		er.Unresolved = append(er.Unresolved, field)
+		switch t.missingFields {
+		case MissingFieldZero:
+			if setter != nil && t.fieldIndex[field].kind != kindPlural {
+				setter.SetTranslationFieldValue(field, "")
+			} else if f := v.Field(t.fieldIndex[field].index); f.CanSet() {
+				f.Set(reflect.Zero(f.Type()))
+			}
+		case MissingFieldPlaceholder:
+			if err := set(field, fmt.Sprintf(t.placeholderFormat, field)); err != nil {
+				return EntityReport{}, err
+			}
+		}
+	}
+	return er, nil
+}
+
+// provenance describes where tr came from for an entity requested in locale.
+func (t *translator[T]) provenance(tr Translation, locale Locale, s *loadState) FieldProvenance {
+	source := SourceRepository
+	if s.hits.hit(tr.Locale, t.entityName, tr.EntityID) {
+		source = SourceCache
+	}
+	p := FieldProvenance{
+		Locale:        tr.Locale,
+		Fallback:      tr.Locale != locale,
+		Source:        source,
+		TranslationID: tr.ID,
+	}
+	if s.sourceHashes != nil && tr.Locale != t.sourceLocale {
+		h, ok := s.sourceHashes[fieldKey{tr.EntityID, t.baseField(tr.Field)}]
+		p.Stale = ok && h != tr.SourceHash
+	}
+	return p
+}
+
+// newEntity returns a zero T whose methods are safe to call. For pointer types
+// that means a pointer to a zero struct rather than a nil pointer, so value
+// receivers don't panic.
+func newEntity[T Translatable]() T {
 	var zero T
+	if typ := reflect.TypeOf(&zero).Elem(); typ.Kind() == reflect.Ptr {
+		return reflect.New(typ.Elem()).Interface().(T)
+	}
+	return zero
+}
+
+// isNil reports whether e is a nil pointer. Always false for struct types.
+// For pointer types the zero T is the nil pointer, so comparing against it
+// needs no reflection.
+func (t *translator[T]) isNil(e T) bool {
+	var zero T
+	return t.isPtr && any(e) == any(zero)
+}
+
+// accessorTarget returns the value optional accessor interfaces are detected
+// on: *T for struct types, T itself for pointer types.
+func (t *translator[T]) accessorTarget(e *T) any {
+	if t.isPtr {
+		return *e
+	}
+	return e
+}
+
+// structValue returns the addressable struct behind e, dereferencing pointer types.
+func (t *translator[T]) structValue(e *T) reflect.Value {
+	v := reflect.ValueOf(e).Elem()
+	if t.isPtr {
+		v = v.Elem()
+	}
+	return v
+}
+
+// buildFieldIndex builds a map from DB field ID → struct field index and kind
+// for type T. Fields of unsupported types are left out.
+// Pre-built once at translator construction — never recalculated per request.
+func buildFieldIndex[T Translatable](zero T) map[string]fieldInfo {
 	fieldMap := zero.TranslatableFields()
 	typ := reflect.TypeOf(zero)
 	if typ.Kind() == reflect.Ptr {
@@ -245,46 +1064,91 @@ func buildFieldIndex[T Translatable]() map[string]int {
 	}
 
 	// Map DB field IDs → struct indices using fieldMap (only translatable fields).
-	idToIndex := make(map[string]int, len(fieldMap))
+	idToIndex := make(map[string]fieldInfo, len(fieldMap))
 	for structName, dbID := range fieldMap {
-		if idx, ok := nameToIdx[structName]; ok {
-			idToIndex[dbID] = idx
+		idx, ok := nameToIdx[structName]
+		if !ok {
+			continue
 		}
+		sf := typ.Field(idx)
+		kind := fieldKindOf(sf.Type)
+		if kind == 0 || kind == kindNullable && !sf.IsExported() {
+			continue
+		}
+		idToIndex[dbID] = fieldInfo{index: idx, kind: kind}
 	}
 	return idToIndex
 }
 
-// extractTranslations reads translatable string fields from an entity.
-// Iterates fieldMap directly (only translatable fields) instead of all struct fields.
-func extractTranslations(entity Translatable) []Translation {
-	v := reflect.ValueOf(entity)
-	if v.Kind() == reflect.Ptr {
-		v = v.Elem()
-	}
-	if v.Kind() != reflect.Struct {
-		return nil
+// sortedKeys returns the keys of the field index in a stable order.
+func sortedKeys(m map[string]fieldInfo) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
 	}
+	sort.Strings(keys)
+	return keys
+}
 
-	fieldMap := entity.TranslatableFields()
-	entityName := entity.TranslationEntityName()
+// extractTranslations appends the translatable fields of an entity to dst.
+// Uses TranslationFieldGetter when *T implements it; otherwise walks the
+// pre-built field index with reflection instead of looking fields up by name.
+// Fields without a value (nil *string, nil []string, null wrappers) produce no row.
+// PluralText fields are always read with reflection and produce one row per variant.
+func (t *translator[T]) extractTranslations(dst []Translation, e *T) ([]Translation, error) {
+	entity := *e
 	entityID := entity.TranslationEntityID()
 	locale := entity.TranslationEntityLocale()
+	newTranslation := func(field, value string) Translation {
+		return Translation{
+			Entity:   t.entityName,
+			EntityID: entityID,
+			Field:    field,
+			Locale:   locale,
+			Value:    value,
+		}
+	}
 
-	results := make([]Translation, 0, len(fieldMap))
-	for structFieldName, dbFieldID := range fieldMap {
-		f := v.FieldByName(structFieldName)
-		if !f.IsValid() || f.Kind() != reflect.String {
+	var getter TranslationFieldGetter
+	if t.hasGetter {
+		getter = t.accessorTarget(e).(TranslationFieldGetter)
+	}
+	var v reflect.Value
+	if getter == nil || t.hasPlural {
+		v = t.structValue(e)
+		if getter == nil && v.Kind() != reflect.Struct {
+			return dst, nil
+		}
+	}
+
+	results := dst
+	for _, id := range t.fieldIDs {
+		fi := t.fieldIndex[id]
+		if fi.kind == kindPlural {
+			rows, err := readPlural(v.Field(fi.index), id, locale)
+			if err != nil {
+				return nil, fmt.Errorf("gotrans: save %s.%s: %w", t.entityName, id, err)
+			}
+			for _, row := range rows {
+				results = append(results, newTranslation(row.Field, row.Value))
+			}
 			continue
 		}
-		results = append(results, Translation{
-			Entity:   entityName,
-			EntityID: entityID,
-			Field:    dbFieldID,
-			Locale:   locale,
-			Value:    f.String(),
-		})
+		if getter != nil {
+			if value, ok := getter.TranslationFieldValue(id); ok {
+				results = append(results, newTranslation(id, value))
+			}
+			continue
+		}
+		value, ok, err := readField(v.Field(fi.index), fi.kind)
+		if err != nil {
+			return nil, fmt.Errorf("gotrans: save %s.%s: %w", t.entityName, id, err)
+		}
+		if ok {
+			results = append(results, newTranslation(id, value))
+		}
 	}
-	return results
+	return results, nil
 }
 
 // ------------------------------------------------
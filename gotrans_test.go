@@ -589,3 +589,48 @@ func TestStressTest_CacheStatistics(t *testing.T) {
 	require.Equal(t, int64(0), stats.Deletes)
 }
 
+func TestPointerEntities_LoadTranslations(t *testing.T) {
+	repo := &mockRepo{
+		translations: []Translation{
+			{ID: 1, Entity: "parameter", EntityID: 1, Field: "name", Locale: LocaleEN, Value: "Name EN"},
+			{ID: 2, Entity: "parameter", EntityID: 2, Field: "name", Locale: LocaleFR, Value: "Name FR"},
+		},
+	}
+	paramTrans := NewTranslator[*Parameter](repo)
+
+	first := &Parameter{ID: 1, locale: LocaleEN}
+	second := &Parameter{ID: 2, locale: LocaleFR}
+	parms, err := paramTrans.LoadTranslations(context.Background(), []*Parameter{first, nil, second})
+	require.NoError(t, err)
+	require.Len(t, parms, 3)
+	require.Nil(t, parms[1])
+	// The pointed-to structs are mutated in place.
+	require.Equal(t, "Name EN", first.Name)
+	require.Equal(t, "Name FR", second.Name)
+}
+
+func TestPointerEntities_SaveTranslations(t *testing.T) {
+	repo := &mockRepo{}
+	paramTrans := NewTranslator[*Parameter](repo)
+
+	err := paramTrans.SaveTranslations(context.Background(), []*Parameter{
+		nil,
+		{ID: 1, locale: LocaleEN, Name: "Name EN", Description: "Desc EN"},
+	})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []Translation{
+		{Entity: "parameter", EntityID: 1, Field: "description", Locale: LocaleEN, Value: "Desc EN"},
+		{Entity: "parameter", EntityID: 1, Field: "name", Locale: LocaleEN, Value: "Name EN"},
+	}, repo.saved)
+}
+
+func TestPointerEntities_AllNil(t *testing.T) {
+	repo := &mockRepo{getErr: errTest}
+	paramTrans := NewTranslator[*Parameter](repo)
+	ctx := context.Background()
+
+	parms, err := paramTrans.LoadTranslations(ctx, []*Parameter{nil, nil})
+	require.NoError(t, err, "no IDs to fetch, repository must not be called")
+	require.Len(t, parms, 2)
+	require.NoError(t, paramTrans.SaveTranslations(ctx, []*Parameter{nil}))
+}
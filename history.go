@@ -0,0 +1,293 @@
+package gotrans
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrRevisionMismatch is returned by RevertTranslation for a revision of
+// another entity or of a field the translator doesn't map.
+var ErrRevisionMismatch = errors.New("revision does not belong to this translator")
+
+// RevisionKind tells what a revision did to its translation.
+type RevisionKind uint8
+
+const (
+	RevisionCreate RevisionKind = iota + 1
+	RevisionUpdate
+	RevisionDelete
+)
+
+func (k RevisionKind) String() string {
+	switch k {
+	case RevisionCreate:
+		return "create"
+	case RevisionUpdate:
+		return "update"
+	case RevisionDelete:
+		return "delete"
+	}
+	return "unknown"
+}
+
+// Revision is one recorded change of a translation. OldValue is empty for a
+// create and NewValue for a delete.
+type Revision struct {
+	ID int64 // assigned by the HistoryStore
+	TranslationKey
+	Kind     RevisionKind
+	OldValue string
+	NewValue string
+	Actor    string
+	At       time.Time
+}
+
+// RetentionPolicy selects revisions for HistoryStore.PruneRevisions. A zero
+// field disables that limit.
+type RetentionPolicy struct {
+	// MaxAge prunes revisions older than this.
+	MaxAge time.Duration
+	// MaxRevisions keeps at most this many of the newest revisions per
+	// TranslationKey.
+	MaxRevisions int
+}
+
+// HistoryStore persists revisions recorded by NewHistoryRepository.
+type HistoryStore interface {
+	// AppendRevisions stores revs, assigning their IDs.
+	AppendRevisions(ctx context.Context, revs []Revision) error
+	// Revisions returns the revisions of one translation, newest first.
+	Revisions(ctx context.Context, key TranslationKey) ([]Revision, error)
+	// PruneRevisions deletes the revisions policy selects and returns how
+	// many it deleted.
+	PruneRevisions(ctx context.Context, policy RetentionPolicy) (int, error)
+}
+
+type actorContextKey struct{}
+
+// WithActor returns a copy of ctx carrying the user or system responsible
+// for changes made with it, as recorded in revisions.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext returns the actor stored by WithActor, or "".
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorContextKey{}).(string)
+	return actor
+}
+
+// HistoryOptions configures NewHistoryRepository.
+type HistoryOptions struct {
+	// Actor extracts the actor from a write's context. Defaults to
+	// ActorFromContext.
+	Actor func(ctx context.Context) string
+	// Now returns the revision timestamp. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// historyRepository records every change made through it in a HistoryStore.
+// It reads the current values before each write to compute the revisions.
+type historyRepository struct {
+	repo  TranslationRepository
+	store HistoryStore
+	actor func(context.Context) string
+	now   func() time.Time
+}
+
+// NewHistoryRepository wraps repo so that every write is recorded in store
+// with its old and new value, timestamp and actor. Wrap the repository
+// below any cache so the old values are read from storage:
+//
+//	history := gotrans.NewInMemoryHistoryStore()
+//	repo := gotrans.NewCachedRepositoryInMemory(
+//	    gotrans.NewHistoryRepository(mysqlRepo, history, gotrans.HistoryOptions{}),
+//	    gotrans.CacheOptions{TTL: 5 * time.Minute})
+//
+// Revisions are appended after the write succeeds; a failure to append is
+// returned but doesn't undo the write. A MassDelete with LocaleNone reads
+// every listed locale (AllLocales) to record what it deletes, and one
+// without entity IDs is not recorded.
+func NewHistoryRepository(repo TranslationRepository, store HistoryStore, opts HistoryOptions) TranslationRepository {
+	h := &historyRepository{repo: repo, store: store, actor: opts.Actor, now: opts.Now}
+	if h.actor == nil {
+		h.actor = ActorFromContext
+	}
+	if h.now == nil {
+		h.now = time.Now
+	}
+	return h
+}
+
+func (h *historyRepository) GetTranslations(ctx context.Context, locale Locale, entity string, entityIDs []int) ([]Translation, error) {
+	return h.repo.GetTranslations(ctx, locale, entity, entityIDs)
+}
+
+func (h *historyRepository) MassCreateOrUpdate(ctx context.Context, locale Locale, translations []Translation) error {
+	if len(translations) == 0 {
+		return h.repo.MassCreateOrUpdate(ctx, locale, translations)
+	}
+
+	// The write replaces exactly the (entity, ID, field) keys of the batch,
+	// so read those.
+	written := make(map[TranslationKey]struct{}, len(translations))
+	ids := make(map[string][]int)
+	for _, tr := range translations {
+		written[tr.Key()] = struct{}{}
+		ids[tr.Entity] = append(ids[tr.Entity], tr.EntityID)
+	}
+	before := make(map[TranslationKey]string)
+	for entity, entityIDs := range ids {
+		trs, err := h.repo.GetTranslations(ctx, locale, entity, uniqueInts(entityIDs))
+		if err != nil {
+			return err
+		}
+		for _, tr := range trs {
+			if _, ok := written[tr.Key()]; ok {
+				before[tr.Key()] = tr.Value
+			}
+		}
+	}
+
+	if err := h.repo.MassCreateOrUpdate(ctx, locale, translations); err != nil {
+		return err
+	}
+
+	actor, at := h.actor(ctx), h.now()
+	var revs []Revision
+	for _, tr := range translations {
+		key := tr.Key()
+		old, existed := before[key]
+		switch {
+		case !existed:
+			revs = append(revs, Revision{TranslationKey: key, Kind: RevisionCreate, NewValue: tr.Value, Actor: actor, At: at})
+		case old != tr.Value:
+			revs = append(revs, Revision{TranslationKey: key, Kind: RevisionUpdate, OldValue: old, NewValue: tr.Value, Actor: actor, At: at})
+		}
+	}
+	return h.append(ctx, revs)
+}
+
+func (h *historyRepository) MassDelete(ctx context.Context, locale Locale, entity string, entityIDs []int, fields []string) error {
+	var deleted []Translation
+	if len(entityIDs) > 0 {
+		locales := []Locale{locale}
+		if locale == LocaleNone {
+			locales = AllLocales()
+		}
+		for _, l := range locales {
+			trs, err := h.repo.GetTranslations(ctx, l, entity, entityIDs)
+			if err != nil {
+				return err
+			}
+			for _, tr := range trs {
+				if len(fields) == 0 || slices.Contains(fields, tr.Field) {
+					deleted = append(deleted, tr)
+				}
+			}
+		}
+	}
+
+	if err := h.repo.MassDelete(ctx, locale, entity, entityIDs, fields); err != nil {
+		return err
+	}
+
+	actor, at := h.actor(ctx), h.now()
+	revs := make([]Revision, len(deleted))
+	for i, tr := range deleted {
+		revs[i] = Revision{TranslationKey: tr.Key(), Kind: RevisionDelete, OldValue: tr.Value, Actor: actor, At: at}
+	}
+	return h.append(ctx, revs)
+}
+
+// append stores revs in a stable order.
+func (h *historyRepository) append(ctx context.Context, revs []Revision) error {
+	if len(revs) == 0 {
+		return nil
+	}
+	sort.Slice(revs, func(i, j int) bool { return revisionKeyLess(revs[i].TranslationKey, revs[j].TranslationKey) })
+	return h.store.AppendRevisions(ctx, revs)
+}
+
+func revisionKeyLess(a, b TranslationKey) bool {
+	if a.Entity != b.Entity {
+		return a.Entity < b.Entity
+	}
+	if a.EntityID != b.EntityID {
+		return a.EntityID < b.EntityID
+	}
+	if a.Field != b.Field {
+		return a.Field < b.Field
+	}
+	return a.Locale < b.Locale
+}
+
+// -----------------------------------------------------------------------------
+// In-memory history store
+// -----------------------------------------------------------------------------
+
+// InMemoryHistoryStore is a thread-safe HistoryStore that keeps revisions in
+// memory, for tests and single-process tools.
+type InMemoryHistoryStore struct {
+	mu     sync.RWMutex
+	nextID int64
+	byKey  map[TranslationKey][]Revision // oldest first
+}
+
+// NewInMemoryHistoryStore returns an empty in-memory history store.
+func NewInMemoryHistoryStore() *InMemoryHistoryStore {
+	return &InMemoryHistoryStore{byKey: make(map[TranslationKey][]Revision)}
+}
+
+func (s *InMemoryHistoryStore) AppendRevisions(_ context.Context, revs []Revision) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range revs {
+		s.nextID++
+		revs[i].ID = s.nextID
+		s.byKey[revs[i].TranslationKey] = append(s.byKey[revs[i].TranslationKey], revs[i])
+	}
+	return nil
+}
+
+func (s *InMemoryHistoryStore) Revisions(_ context.Context, key TranslationKey) ([]Revision, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	stored := s.byKey[key]
+	revs := make([]Revision, len(stored))
+	for i, r := range stored {
+		revs[len(stored)-1-i] = r
+	}
+	return revs, nil
+}
+
+func (s *InMemoryHistoryStore) PruneRevisions(_ context.Context, policy RetentionPolicy) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cutoff := time.Time{}
+	if policy.MaxAge > 0 {
+		cutoff = time.Now().Add(-policy.MaxAge)
+	}
+	pruned := 0
+	for key, revs := range s.byKey {
+		kept := revs[:0]
+		for i, r := range revs {
+			tooMany := policy.MaxRevisions > 0 && len(revs)-i > policy.MaxRevisions
+			if tooMany || r.At.Before(cutoff) {
+				pruned++
+				continue
+			}
+			kept = append(kept, r)
+		}
+		if len(kept) == 0 {
+			delete(s.byKey, key)
+		} else {
+			s.byKey[key] = kept
+		}
+	}
+	return pruned, nil
+}
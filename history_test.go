@@ -0,0 +1,141 @@
+package gotrans
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// savedRepo is a mockRepo whose reads see its own writes.
+type savedRepo struct{ mockRepo }
+
+func (r *savedRepo) GetTranslations(ctx context.Context, locale Locale, entity string, entityIDs []int) ([]Translation, error) {
+	r.mu.Lock()
+	view := &mockRepo{translations: append([]Translation(nil), r.saved...)}
+	r.mu.Unlock()
+	return view.GetTranslations(ctx, locale, entity, entityIDs)
+}
+
+func TestHistoryRepository_RecordsChanges(t *testing.T) {
+	base := &savedRepo{}
+	store := NewInMemoryHistoryStore()
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	repo := NewHistoryRepository(base, store, HistoryOptions{Now: func() time.Time { return now }})
+	trans := NewTranslator[Parameter](repo)
+	ctx := WithActor(context.Background(), "alice")
+
+	require.NoError(t, trans.SaveTranslations(ctx, []Parameter{{ID: 1, locale: LocaleEN, Name: "Size", Description: "Box size"}}))
+	require.NoError(t, trans.SaveTranslations(WithActor(ctx, "bob"), []Parameter{{ID: 1, locale: LocaleEN, Name: "Dimensions", Description: "Box size"}}))
+	require.NoError(t, trans.DeleteTranslations(ctx, LocaleEN, []int{1}, []string{"description"}))
+
+	key := TranslationKey{Entity: "parameter", EntityID: 1, Field: "name", Locale: LocaleEN}
+	revs, err := store.Revisions(ctx, key)
+	require.NoError(t, err)
+	require.Len(t, revs, 2)
+	require.Equal(t, Revision{ID: revs[0].ID, TranslationKey: key, Kind: RevisionUpdate, OldValue: "Size", NewValue: "Dimensions", Actor: "bob", At: now}, revs[0])
+	require.Equal(t, RevisionCreate, revs[1].Kind)
+	require.Equal(t, "alice", revs[1].Actor)
+
+	key.Field = "description"
+	revs, err = store.Revisions(ctx, key)
+	require.NoError(t, err)
+	require.Len(t, revs, 2, "the unchanged description is not recorded twice")
+	require.Equal(t, RevisionDelete, revs[0].Kind)
+	require.Equal(t, "Box size", revs[0].OldValue)
+}
+
+func TestTranslator_RevertTranslation(t *testing.T) {
+	base := &savedRepo{}
+	store := NewInMemoryHistoryStore()
+	trans := NewTranslatorWithOptions(TranslatorOptions[Parameter]{
+		Repository: NewHistoryRepository(base, store, HistoryOptions{}),
+		Validators: []TranslationValidator{RuleValidator{Rules: []Rule{MaxLength{Max: 8}}}},
+	})
+	ctx := context.Background()
+
+	require.NoError(t, trans.SaveTranslations(ctx, []Parameter{{ID: 1, locale: LocaleFR, Name: "Taille"}}))
+	require.NoError(t, trans.SaveTranslations(ctx, []Parameter{{ID: 1, locale: LocaleFR, Name: "Format"}}))
+	key := TranslationKey{Entity: "parameter", EntityID: 1, Field: "name", Locale: LocaleFR}
+	revs, err := store.Revisions(ctx, key)
+	require.NoError(t, err)
+
+	require.NoError(t, trans.RevertTranslation(ctx, revs[1]))
+	got, err := trans.LoadTranslations(ctx, []Parameter{{ID: 1, locale: LocaleFR}})
+	require.NoError(t, err)
+	require.Equal(t, "Taille", got[0].Name)
+
+	revs, err = store.Revisions(ctx, key)
+	require.NoError(t, err)
+	require.Len(t, revs, 3, "the revert is itself a revision")
+	require.Equal(t, "Format", revs[0].OldValue)
+
+	err = trans.RevertTranslation(ctx, Revision{TranslationKey: key, Kind: RevisionUpdate, NewValue: "Beaucoup trop long"})
+	var ve *ValidationError
+	require.ErrorAs(t, err, &ve, "reverts go through the validators")
+
+	err = trans.RevertTranslation(ctx, Revision{TranslationKey: TranslationKey{Entity: "product", EntityID: 1, Field: "name", Locale: LocaleFR}})
+	require.ErrorIs(t, err, ErrRevisionMismatch)
+	require.Equal(t, KindValidation, KindOf(err))
+}
+
+func TestInMemoryHistoryStore_Prune(t *testing.T) {
+	store := NewInMemoryHistoryStore()
+	ctx := context.Background()
+	key := TranslationKey{Entity: "parameter", EntityID: 1, Field: "name", Locale: LocaleEN}
+	old := time.Now().Add(-48 * time.Hour)
+	require.NoError(t, store.AppendRevisions(ctx, []Revision{
+		{TranslationKey: key, NewValue: "a", At: old},
+		{TranslationKey: key, NewValue: "b", At: time.Now()},
+		{TranslationKey: key, NewValue: "c", At: time.Now()},
+		{TranslationKey: key, NewValue: "d", At: time.Now()},
+	}))
+
+	n, err := store.PruneRevisions(ctx, RetentionPolicy{MaxAge: 24 * time.Hour})
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	n, err = store.PruneRevisions(ctx, RetentionPolicy{MaxRevisions: 2})
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	revs, err := store.Revisions(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, "d", revs[0].NewValue)
+	require.Equal(t, "c", revs[1].NewValue)
+	require.Len(t, revs, 2)
+}
+
+func TestHistoryRepository_MultiEntitySave(t *testing.T) {
+	base := &savedRepo{}
+	store := NewInMemoryHistoryStore()
+	trans := NewTranslatorWithOptions(TranslatorOptions[Parameter]{
+		Repository:  NewHistoryRepository(base, store, HistoryOptions{}),
+		EmptyValues: EmptyValueSkip,
+	})
+	ctx := context.Background()
+
+	require.NoError(t, trans.SaveTranslations(ctx, []Parameter{
+		{ID: 1, locale: LocaleEN, Name: "Size", Description: "Box size"},
+		{ID: 2, locale: LocaleEN, Name: "Weight", Description: "Box weight"},
+	}))
+	require.NoError(t, trans.SaveTranslations(ctx, []Parameter{
+		{ID: 1, locale: LocaleEN, Name: "Dimensions"},
+		{ID: 2, locale: LocaleEN, Description: "Gross weight"},
+	}))
+
+	kinds := func(id int, field string) []RevisionKind {
+		revs, err := store.Revisions(ctx, TranslationKey{Entity: "parameter", EntityID: id, Field: field, Locale: LocaleEN})
+		require.NoError(t, err)
+		var kinds []RevisionKind
+		for _, r := range revs {
+			kinds = append(kinds, r.Kind)
+		}
+		return kinds
+	}
+	require.Equal(t, []RevisionKind{RevisionUpdate, RevisionCreate}, kinds(1, "name"))
+	require.Equal(t, []RevisionKind{RevisionCreate}, kinds(1, "description"), "a skipped field is not recorded as deleted")
+	require.Equal(t, []RevisionKind{RevisionCreate}, kinds(2, "name"))
+	require.Equal(t, []RevisionKind{RevisionUpdate, RevisionCreate}, kinds(2, "description"))
+	require.Len(t, base.saved, 4)
+}
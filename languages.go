@@ -1,7 +1,20 @@
 package gotrans
 
-import "strings"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
 
+// Locale identifies a language, optionally narrowed by a BCP 47 script and
+// region subtag: en, en-GB, zh-Hant, sr-Latn-RS. The bare languages and the
+// most common regional variants are constants; other tags get a value from
+// RegisterLocale. Values are stable only within a process, so persist
+// Locale.String(), not the number.
 type Locale int16
 
 const (
@@ -47,88 +60,325 @@ const (
 	LocaleUK          // Ukrainian
 	LocaleVI          // Vietnamese
 	LocaleIT          // Italian
+
+	LocaleENGB   // English (United Kingdom)
+	LocaleENUS   // English (United States)
+	LocalePTBR   // Portuguese (Brazil)
+	LocalePTPT   // Portuguese (Portugal)
+	LocaleZHHans // Chinese (Simplified)
+	LocaleZHHant // Chinese (Traditional)
+	LocaleSRLatn // Serbian (Latin)
+
+	localeBuiltinEnd
 )
 
 type langInfo struct {
-	code string
-	name string
+	code    string // canonical BCP 47 tag
+	name    string
+	native  string         // name in the language itself
+	primary string         // ISO 15924 script the language is written in; see PrimaryScript
+	rtl     bool           // registered as right-to-left regardless of script
+	plural  *pluralRuleSet // registered plural rules; built-ins use pluralRules
+	parent  Locale         // the tag minus its last subtag; LocaleNone for a bare language
+	script  string
+	region  string
 }
 
 // Main ISO-639-1 registry
 var languages = map[Locale]langInfo{
-	LocaleSQ: {"sq", "Albanian"},
-	LocaleAR: {"ar", "Arabic"},
-	LocaleAZ: {"az", "Azerbaijani"},
-	LocaleBS: {"bs", "Bosnian"},
-	LocaleBG: {"bg", "Bulgarian"},
-	LocaleZH: {"zh", "Chinese"},
-	LocaleHR: {"hr", "Croatian"},
-	LocaleCS: {"cs", "Czech"},
-	LocaleDA: {"da", "Danish"},
-	LocaleNL: {"nl", "Dutch"},
-	LocaleEN: {"en", "English"},
-	LocaleET: {"et", "Estonian"},
-	LocaleFI: {"fi", "Finnish"},
-	LocaleFR: {"fr", "French"},
-	LocaleKA: {"ka", "Georgian"},
-	LocaleDE: {"de", "German"},
-	LocaleEL: {"el", "Greek"},
-	LocaleHE: {"he", "Hebrew"},
-	LocaleHU: {"hu", "Hungarian"},
-	LocaleID: {"id", "Indonesian"},
-	LocaleJA: {"ja", "Japanese"},
-	LocaleKK: {"kk", "Kazakh"},
-	LocaleKO: {"ko", "Korean"},
-	LocaleLV: {"lv", "Latvian"},
-	LocaleLT: {"lt", "Lithuanian"},
-	LocaleMK: {"mk", "Macedonian"},
-	LocaleNO: {"no", "Norwegian"},
-	LocalePL: {"pl", "Polish"},
-	LocalePT: {"pt", "Portuguese"},
-	LocaleRO: {"ro", "Romanian"},
-	LocaleRU: {"ru", "Russian"},
-	LocaleSR: {"sr", "Serbian"},
-	LocaleSK: {"sk", "Slovak"},
-	LocaleSL: {"sl", "Slovenian"},
-	LocaleES: {"es", "Spanish"},
-	LocaleSV: {"sv", "Swedish"},
-	LocaleTH: {"th", "Thai"},
-	LocaleTR: {"tr", "Turkish"},
-	LocaleUK: {"uk", "Ukrainian"},
-	LocaleVI: {"vi", "Vietnamese"},
-	LocaleIT: {"it", "Italian"},
-}
-
-// Acceptable aliases, including BCP47 fallbacks
-var aliases = map[string]Locale{
-	"zh-hant": LocaleZH,
-	"zh-hans": LocaleZH,
-	"sr-latn": LocaleSR,
-	"pt-br":   LocalePT,
-}
-
-// Map lookup table built at init time.
-// Includes "none" so ParseLocale(LocaleNone.String()) round-trips correctly.
-var codeToLocale = func() map[string]Locale {
-	m := make(map[string]Locale)
-	m["none"] = LocaleNone
+	LocaleSQ: {code: "sq", name: "Albanian", native: "shqip", primary: "Latn"},
+	LocaleAR: {code: "ar", name: "Arabic", native: "العربية", primary: "Arab"},
+	LocaleAZ: {code: "az", name: "Azerbaijani", native: "azərbaycan", primary: "Latn"},
+	LocaleBS: {code: "bs", name: "Bosnian", native: "bosanski", primary: "Latn"},
+	LocaleBG: {code: "bg", name: "Bulgarian", native: "български", primary: "Cyrl"},
+	LocaleZH: {code: "zh", name: "Chinese", native: "中文", primary: "Hans"},
+	LocaleHR: {code: "hr", name: "Croatian", native: "hrvatski", primary: "Latn"},
+	LocaleCS: {code: "cs", name: "Czech", native: "čeština", primary: "Latn"},
+	LocaleDA: {code: "da", name: "Danish", native: "dansk", primary: "Latn"},
+	LocaleNL: {code: "nl", name: "Dutch", native: "Nederlands", primary: "Latn"},
+	LocaleEN: {code: "en", name: "English", native: "English", primary: "Latn"},
+	LocaleET: {code: "et", name: "Estonian", native: "eesti", primary: "Latn"},
+	LocaleFI: {code: "fi", name: "Finnish", native: "suomi", primary: "Latn"},
+	LocaleFR: {code: "fr", name: "French", native: "français", primary: "Latn"},
+	LocaleKA: {code: "ka", name: "Georgian", native: "ქართული", primary: "Geor"},
+	LocaleDE: {code: "de", name: "German", native: "Deutsch", primary: "Latn"},
+	LocaleEL: {code: "el", name: "Greek", native: "Ελληνικά", primary: "Grek"},
+	LocaleHE: {code: "he", name: "Hebrew", native: "עברית", primary: "Hebr"},
+	LocaleHU: {code: "hu", name: "Hungarian", native: "magyar", primary: "Latn"},
+	LocaleID: {code: "id", name: "Indonesian", native: "Indonesia", primary: "Latn"},
+	LocaleJA: {code: "ja", name: "Japanese", native: "日本語", primary: "Jpan"},
+	LocaleKK: {code: "kk", name: "Kazakh", native: "қазақ тілі", primary: "Cyrl"},
+	LocaleKO: {code: "ko", name: "Korean", native: "한국어", primary: "Kore"},
+	LocaleLV: {code: "lv", name: "Latvian", native: "latviešu", primary: "Latn"},
+	LocaleLT: {code: "lt", name: "Lithuanian", native: "lietuvių", primary: "Latn"},
+	LocaleMK: {code: "mk", name: "Macedonian", native: "македонски", primary: "Cyrl"},
+	LocaleNO: {code: "no", name: "Norwegian", native: "norsk", primary: "Latn"},
+	LocalePL: {code: "pl", name: "Polish", native: "polski", primary: "Latn"},
+	LocalePT: {code: "pt", name: "Portuguese", native: "português", primary: "Latn"},
+	LocaleRO: {code: "ro", name: "Romanian", native: "română", primary: "Latn"},
+	LocaleRU: {code: "ru", name: "Russian", native: "русский", primary: "Cyrl"},
+	LocaleSR: {code: "sr", name: "Serbian", native: "српски", primary: "Cyrl"},
+	LocaleSK: {code: "sk", name: "Slovak", native: "slovenčina", primary: "Latn"},
+	LocaleSL: {code: "sl", name: "Slovenian", native: "slovenščina", primary: "Latn"},
+	LocaleES: {code: "es", name: "Spanish", native: "español", primary: "Latn"},
+	LocaleSV: {code: "sv", name: "Swedish", native: "svenska", primary: "Latn"},
+	LocaleTH: {code: "th", name: "Thai", native: "ไทย", primary: "Thai"},
+	LocaleTR: {code: "tr", name: "Turkish", native: "Türkçe", primary: "Latn"},
+	LocaleUK: {code: "uk", name: "Ukrainian", native: "українська", primary: "Cyrl"},
+	LocaleVI: {code: "vi", name: "Vietnamese", native: "Tiếng Việt", primary: "Latn"},
+	LocaleIT: {code: "it", name: "Italian", native: "italiano", primary: "Latn"},
+
+	LocaleENGB:   {code: "en-GB", name: "English (United Kingdom)", native: "British English", parent: LocaleEN, region: "GB"},
+	LocaleENUS:   {code: "en-US", name: "English (United States)", native: "American English", parent: LocaleEN, region: "US"},
+	LocalePTBR:   {code: "pt-BR", name: "Portuguese (Brazil)", native: "português (Brasil)", parent: LocalePT, region: "BR"},
+	LocalePTPT:   {code: "pt-PT", name: "Portuguese (Portugal)", native: "português europeu", parent: LocalePT, region: "PT"},
+	LocaleZHHans: {code: "zh-Hans", name: "Chinese (Simplified)", native: "简体中文", parent: LocaleZH, script: "Hans"},
+	LocaleZHHant: {code: "zh-Hant", name: "Chinese (Traditional)", native: "繁體中文", parent: LocaleZH, script: "Hant"},
+	LocaleSRLatn: {code: "sr-Latn", name: "Serbian (Latin)", native: "srpski (latinica)", parent: LocaleSR, script: "Latn"},
+}
+
+// ISO 15924 script codes accepted as script subtags.
+var scriptCodes = setOf(`
+	Arab Armn Beng Cyrl Deva Ethi Geor Grek Gujr Guru Hang Hani Hans Hant Hebr
+	Hira Jpan Kana Khmr Knda Kore Laoo Latn Mlym Mong Mymr Orya Sinh Taml Telu
+	Thaa Thai Tibt`)
+
+func setOf(list string) map[string]bool {
+	m := make(map[string]bool)
+	for _, s := range strings.Fields(list) {
+		m[s] = true
+	}
+	return m
+}
+
+// localeRegistry is an immutable snapshot of every known Locale. Readers load
+// it without locking; RegisterLocale replaces it.
+type localeRegistry struct {
+	info   map[Locale]langInfo
+	byCode map[string]Locale // lower-case tag → Locale; includes "none"
+	next   Locale
+}
+
+var (
+	registry   atomic.Pointer[localeRegistry]
+	registryMu sync.Mutex // serializes writers
+)
+
+func init() {
+	r := &localeRegistry{
+		info:   make(map[Locale]langInfo, len(languages)),
+		byCode: map[string]Locale{"none": LocaleNone},
+		next:   localeBuiltinEnd,
+	}
 	for l, info := range languages {
-		m[info.code] = l
+		r.info[l] = info
+		r.byCode[strings.ToLower(info.code)] = l
 	}
-	for alias, locale := range aliases {
-		m[alias] = locale
+	registry.Store(r)
+}
+
+// clone returns a copy of r for a writer to modify.
+func (r *localeRegistry) clone() *localeRegistry {
+	c := &localeRegistry{
+		info:   make(map[Locale]langInfo, len(r.info)+1),
+		byCode: make(map[string]Locale, len(r.byCode)+1),
+		next:   r.next,
 	}
-	return m
-}()
+	for l, info := range r.info {
+		c.info[l] = info
+	}
+	for code, l := range r.byCode {
+		c.byCode[code] = l
+	}
+	return c
+}
 
-// ParseLocale returns a Locale enum from a language code (ISO-639-1).
-// Returns (LocaleNone, false) for unknown codes.
+func (l Locale) info() (langInfo, bool) {
+	info, ok := registry.Load().info[l]
+	return info, ok
+}
+
+// ParseLocale returns the Locale for a language code (ISO-639-1) or a BCP 47
+// tag that is built in or was added with RegisterLocale, in hyphen or
+// underscore form and any case: "en", "en-GB", "pt_br", "ZH-hant". Returns
+// (LocaleNone, false) for any other code, including unregistered variants
+// of a known language such as "fr-CH"; ParseLocaleMode with ParseLenient
+// maps those to their nearest known parent. ParseLocale never adds to the
+// registry, so it is safe on untrusted input.
 func ParseLocale(code string) (Locale, bool) {
-	code = strings.ToLower(strings.TrimSpace(code))
-	l, ok := codeToLocale[code]
+	code = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(code)), "_", "-")
+	l, ok := registry.Load().byCode[code]
 	return l, ok
 }
 
+func isAlpha(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 'a' || s[i] > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlnum(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if (s[i] < 'a' || s[i] > 'z') && (s[i] < '0' || s[i] > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// ErrLocaleConflict is returned by RegisterLocale for a code that is already registered.
+var ErrLocaleConflict = errors.New("locale code already registered")
+
+// LocaleDefinition describes a locale added with RegisterLocale.
+type LocaleDefinition struct {
+	// Code is a BCP 47 tag: a 2–8 letter language followed by subtags of
+	// 1–8 letters or digits, e.g. "ca", "sw-KE" or the pseudo-locales
+	// "en-XA" and "qps-ploc". Underscores are accepted for hyphens and case
+	// is canonicalized.
+	Code string
+	// Name is the English name, e.g. "Catalan".
+	Name string
+	// NativeName is the name in the language itself, e.g. "Català".
+	NativeName string
+	// Script is the ISO 15924 script the language is written in, e.g.
+	// "Deva" for Hindi. It defaults to the script subtag of Code, then to
+	// the parent's script.
+	Script string
+	// Direction RightToLeft marks the locale as right-to-left; otherwise
+	// the direction follows Script.
+	Direction TextDirection
+	// PluralCategories and PluralRule are the CLDR cardinal plural rules:
+	// the categories the language uses, which must include PluralOther, and
+	// the function choosing one. Set both or neither; without them the
+	// locale uses its parent's rules, or PluralOther for every count.
+	PluralCategories []PluralCategory
+	PluralRule       func(PluralOperands) PluralCategory
+}
+
+// RegisterLocale adds a locale that ParseLocale, AllLocales, String and the
+// repositories then treat like a built-in one. Its parent, for fallback and
+// for plural and format rules, is the longest known prefix of the tag: en-XA
+// falls back to en. Registering a code that is built in or was registered
+// before fails with ErrLocaleConflict. Register locales during
+// initialization, before they are parsed from input.
+func RegisterLocale(def LocaleDefinition) (Locale, error) {
+	code, ok := canonicalTag(def.Code)
+	if !ok {
+		return LocaleNone, fmt.Errorf("gotrans: invalid locale code %q", def.Code)
+	}
+	if def.Name == "" {
+		return LocaleNone, fmt.Errorf("gotrans: locale %s has no name", code)
+	}
+	var plural *pluralRuleSet
+	switch {
+	case def.PluralRule == nil && len(def.PluralCategories) == 0:
+	case def.PluralRule == nil || !slices.Contains(def.PluralCategories, PluralOther):
+		return LocaleNone, fmt.Errorf("gotrans: locale %s needs both a plural rule and categories including %q", code, PluralOther)
+	default:
+		plural = &pluralRuleSet{slices.Clone(def.PluralCategories), def.PluralRule}
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	c := registry.Load().clone()
+	key := strings.ToLower(code)
+	if l, exists := c.byCode[key]; exists {
+		return LocaleNone, fmt.Errorf("%w: %s (%s)", ErrLocaleConflict, code, c.info[l].name)
+	}
+	if c.next == math.MaxInt16 {
+		return LocaleNone, fmt.Errorf("gotrans: no locale numbers left for %s", code)
+	}
+	l := c.next
+	c.next++
+	info := langInfo{
+		code:    code,
+		name:    def.Name,
+		native:  def.NativeName,
+		primary: def.Script,
+		rtl:     def.Direction == RightToLeft,
+		plural:  plural,
+	}
+	for prefix := code; info.parent == LocaleNone; {
+		i := strings.LastIndexByte(prefix, '-')
+		if i < 0 {
+			break
+		}
+		prefix = prefix[:i]
+		info.parent = c.byCode[strings.ToLower(prefix)]
+	}
+	if parts := strings.Split(code, "-"); info.parent != LocaleNone {
+		parent := c.info[info.parent]
+		info.script = parent.script
+		info.region = parent.region
+		for _, sub := range parts[1:] {
+			switch {
+			case scriptCodes[sub]:
+				info.script = sub
+			case len(sub) == 2 && isAlpha(strings.ToLower(sub)), len(sub) == 3 && isDigits(sub):
+				info.region = sub
+			}
+		}
+	}
+	if info.primary == "" {
+		info.primary = info.script
+	}
+	for p := info.parent; info.primary == "" && p != LocaleNone; p = c.info[p].parent {
+		info.primary = c.info[p].script
+		if info.primary == "" {
+			info.primary = c.info[p].primary
+		}
+	}
+	c.info[l] = info
+	c.byCode[key] = l
+	registry.Store(c)
+	return l, nil
+}
+
+// MustRegisterLocale is RegisterLocale that panics on error, for use in
+// package-level variable declarations.
+func MustRegisterLocale(def LocaleDefinition) Locale {
+	l, err := RegisterLocale(def)
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
+
+// canonicalTag validates a BCP 47 tag and returns it with canonical case:
+// lower-case language, title-case script, upper-case region, lower-case rest.
+func canonicalTag(code string) (string, bool) {
+	parts := strings.Split(strings.ReplaceAll(strings.TrimSpace(code), "_", "-"), "-")
+	for i, p := range parts {
+		p = strings.ToLower(p)
+		switch {
+		case len(p) == 0 || len(p) > 8 || !isAlnum(p):
+			return "", false
+		case i == 0:
+			if len(p) < 2 || !isAlpha(p) {
+				return "", false
+			}
+		case len(p) == 4 && isAlpha(p):
+			p = strings.ToUpper(p[:1]) + p[1:]
+		case len(p) == 2 && isAlpha(p):
+			p = strings.ToUpper(p)
+		}
+		parts[i] = p
+	}
+	return strings.Join(parts, "-"), true
+}
+
 // ParseLocaleList converts "en,ru,uk" into []Locale.
 func ParseLocaleList(list string) []Locale {
 	parts := strings.Split(list, ",")
@@ -142,9 +392,10 @@ func ParseLocaleList(list string) []Locale {
 	return res
 }
 
-// Code returns the ISO-639-1 code for a language.
+// Code returns the canonical BCP 47 tag: the ISO-639-1 code for a bare
+// language, e.g. "en", and e.g. "en-GB" or "zh-Hant" for a variant.
 func (l Locale) Code() string {
-	if info, ok := languages[l]; ok {
+	if info, ok := l.info(); ok {
 		return info.code
 	}
 	return ""
@@ -152,13 +403,22 @@ func (l Locale) Code() string {
 
 // Name returns the human-readable language name.
 func (l Locale) Name() string {
-	if info, ok := languages[l]; ok {
+	if info, ok := l.info(); ok {
 		return info.name
 	}
 	return ""
 }
 
-// String returns the ISO-639-1 code, or "none" for LocaleNone.
+// NativeName returns the name of the language in itself, e.g. "Deutsch",
+// falling back to Name when none is known.
+func (l Locale) NativeName() string {
+	if info, ok := l.info(); ok && info.native != "" {
+		return info.native
+	}
+	return l.Name()
+}
+
+// String returns the canonical tag, or "none" for LocaleNone.
 func (l Locale) String() string {
 	if l == LocaleNone {
 		return "none"
@@ -166,10 +426,106 @@ func (l Locale) String() string {
 	return l.Code()
 }
 
-// AllLocales returns all supported locales in an unspecified order, excluding LocaleNone.
+// TextDirection is the writing direction of a locale.
+type TextDirection uint8
+
+const (
+	LeftToRight TextDirection = iota
+	RightToLeft
+)
+
+func (d TextDirection) String() string {
+	if d == RightToLeft {
+		return "rtl"
+	}
+	return "ltr"
+}
+
+// rtlScripts lists ISO 15924 scripts written right to left.
+var rtlScripts = setOf("Adlm Arab Hebr Nkoo Rohg Syrc Thaa")
+
+// PrimaryScript returns the ISO 15924 script l is written in: its script
+// subtag, otherwise the language's usual script, e.g. "Cyrl" for sr and
+// "Latn" for sr-Latn. It returns "" when unknown.
+func (l Locale) PrimaryScript() string {
+	for ; l != LocaleNone; l = l.Parent() {
+		info, _ := l.info()
+		if info.script != "" {
+			return info.script
+		}
+		if info.primary != "" {
+			return info.primary
+		}
+	}
+	return ""
+}
+
+// Direction returns the writing direction of l: RightToLeft for Arabic and
+// Hebrew and for registered right-to-left locales, LeftToRight otherwise.
+func (l Locale) Direction() TextDirection {
+	for p := l; p != LocaleNone; p = p.Parent() {
+		if info, _ := p.info(); info.rtl {
+			return RightToLeft
+		}
+	}
+	if rtlScripts[l.PrimaryScript()] {
+		return RightToLeft
+	}
+	return LeftToRight
+}
+
+// PluralCategories returns the CLDR cardinal plural categories l uses, in
+// the order zero, one, two, few, many, other. Unknown locales use only
+// PluralOther.
+func (l Locale) PluralCategories() []PluralCategory {
+	return slices.Clone(pluralRuleSetFor(l).categories)
+}
+
+// Parent returns l without its last subtag: zh-Hant-TW → zh-Hant → zh. It
+// returns LocaleNone for a bare language.
+func (l Locale) Parent() Locale {
+	info, _ := l.info()
+	return info.parent
+}
+
+// Base returns the bare language of l, e.g. LocaleEN for en-GB.
+func (l Locale) Base() Locale {
+	for p := l.Parent(); p != LocaleNone; p = p.Parent() {
+		l = p
+	}
+	return l
+}
+
+// Script returns the ISO 15924 script subtag of l, or "".
+func (l Locale) Script() string {
+	info, _ := l.info()
+	return info.script
+}
+
+// Region returns the region subtag of l, or "".
+func (l Locale) Region() string {
+	info, _ := l.info()
+	return info.region
+}
+
+// lookupLocale returns the entry of m for the code of l or of its nearest
+// parent, so tables keyed by language also serve regional variants.
+func lookupLocale[V any](m map[string]V, l Locale) (V, bool) {
+	for ; l != LocaleNone; l = l.Parent() {
+		if v, ok := m[l.Code()]; ok {
+			return v, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// AllLocales returns all supported locales in an unspecified order, excluding
+// LocaleNone.
 func AllLocales() []Locale {
-	result := make([]Locale, 0, len(languages))
-	for l := range languages {
+	r := registry.Load()
+	result := make([]Locale, 0, len(r.info))
+	for l := range r.info {
 		result = append(result, l)
 	}
 	return result
@@ -1,7 +1,10 @@
 package gotrans
 
 import (
+	"errors"
+	"slices"
 	"testing"
+	"time"
 )
 
 func TestParseLocale(t *testing.T) {
@@ -13,8 +16,13 @@ func TestParseLocale(t *testing.T) {
 		{"en", LocaleEN, true},
 		{"ru", LocaleRU, true},
 		{"uk", LocaleUK, true},
-		{"zh-hant", LocaleZH, true},
-		{"pt-br", LocalePT, true},
+		{"zh-hant", LocaleZHHant, true},
+		{"pt-br", LocalePTBR, true},
+		{"pt_BR", LocalePTBR, true},
+		{"EN-gb", LocaleENGB, true},
+		{"en-XX", LocaleNone, false},
+		{"xx-GB", LocaleNone, false},
+		{"en-GB-oxendict", LocaleNone, false},
 		{"unknown", LocaleNone, false},
 		{"", LocaleNone, false},
 	}
@@ -53,3 +61,173 @@ func TestLocale_Code_Name_String(t *testing.T) {
 		t.Errorf("LocaleRU.String() = %q, want %q", locale.String(), "ru")
 	}
 }
+
+func TestParseLocale_RegionalTags(t *testing.T) {
+	size := len(AllLocales())
+	for _, code := range []string{"zh_hant_tw", "es-419", "fr-CH", "en-001"} {
+		if l, ok := ParseLocale(code); ok || l != LocaleNone {
+			t.Errorf("ParseLocale(%q) = (%v, %v), want unregistered", code, l, ok)
+		}
+	}
+	if n := len(AllLocales()); n != size {
+		t.Errorf("parsing grew the registry from %d to %d locales", size, n)
+	}
+	if l, _ := ParseLocaleMode("zh_hant_tw", ParseLenient); l != LocaleZHHant {
+		t.Errorf("lenient zh_hant_tw = %v, want zh-Hant", l)
+	}
+
+	tw := MustRegisterLocale(LocaleDefinition{Code: "zh_hant_tw", Name: "Chinese (Taiwan)"})
+	if tw.Code() != "zh-Hant-TW" || tw.Script() != "Hant" || tw.Region() != "TW" {
+		t.Errorf("got code %q script %q region %q", tw.Code(), tw.Script(), tw.Region())
+	}
+	if tw.Parent() != LocaleZHHant || tw.Base() != LocaleZH {
+		t.Errorf("Parent() = %v, Base() = %v", tw.Parent(), tw.Base())
+	}
+	if again, ok := ParseLocale("ZH-Hant-tw"); !ok || again != tw {
+		t.Errorf("ParseLocale after registering = %v, want %v", again, tw)
+	}
+}
+
+func TestLocale_Variants(t *testing.T) {
+	if LocaleENGB.String() != "en-GB" || LocaleENGB.Name() != "English (United Kingdom)" {
+		t.Errorf("LocaleENGB = %q %q", LocaleENGB.String(), LocaleENGB.Name())
+	}
+	if LocaleEN.Parent() != LocaleNone || LocaleEN.Base() != LocaleEN {
+		t.Errorf("LocaleEN parent %v base %v", LocaleEN.Parent(), LocaleEN.Base())
+	}
+	if PluralCategoryOf(LocalePTBR, 1) != PluralOne {
+		t.Error("pt-BR should use the pt plural rules")
+	}
+	if got := FormatNumber(LocalePTBR, 1234.5); got != "1.234,5" {
+		t.Errorf("FormatNumber(pt-BR) = %q", got)
+	}
+	d := time.Date(2024, 3, 7, 15, 4, 0, 0, time.UTC)
+	if got := FormatDate(LocaleENGB, d, DateShort) + " " + FormatTime(LocaleENGB, d, DateShort); got != "07/03/2024 15:04" {
+		t.Errorf("en-GB date and time = %q", got)
+	}
+}
+
+func TestRegisterLocale(t *testing.T) {
+	ca, err := RegisterLocale(LocaleDefinition{Code: "ca", Name: "Catalan", NativeName: "Català"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := ParseLocale("CA"); !ok || got != ca {
+		t.Errorf("ParseLocale(CA) = %v, %v", got, ok)
+	}
+	if ca.String() != "ca" || ca.Name() != "Catalan" || ca.NativeName() != "Català" || ca.Parent() != LocaleNone {
+		t.Errorf("ca = %q %q %q parent %v", ca.String(), ca.Name(), ca.NativeName(), ca.Parent())
+	}
+	caES := MustRegisterLocale(LocaleDefinition{Code: "ca_ES", Name: "Catalan (Spain)"})
+	if es, ok := ParseLocale("ca-es"); !ok || es != caES || es.Base() != ca {
+		t.Errorf("ParseLocale(ca-es) = %v, %v", es, ok)
+	}
+
+	pseudo := MustRegisterLocale(LocaleDefinition{Code: "en_xa", Name: "English (pseudo-accented)"})
+	if pseudo.String() != "en-XA" || pseudo.Parent() != LocaleEN || pseudo.Region() != "XA" {
+		t.Errorf("en-XA = %q parent %v region %q", pseudo.String(), pseudo.Parent(), pseudo.Region())
+	}
+	if pseudo.NativeName() != "English (pseudo-accented)" {
+		t.Errorf("NativeName falls back to Name, got %q", pseudo.NativeName())
+	}
+
+	found := 0
+	for _, l := range AllLocales() {
+		if l == ca || l == pseudo {
+			found++
+		}
+	}
+	if found != 2 {
+		t.Errorf("AllLocales lists %d of 2 registered locales", found)
+	}
+
+	for _, code := range []string{"ca", "EN", "en-GB", "none"} {
+		if _, err := RegisterLocale(LocaleDefinition{Code: code, Name: "X"}); !errors.Is(err, ErrLocaleConflict) {
+			t.Errorf("RegisterLocale(%q) = %v, want ErrLocaleConflict", code, err)
+		}
+	}
+	for _, code := range []string{"", "e", "en--GB", "en-toolongsubtag", "1a"} {
+		if _, err := RegisterLocale(LocaleDefinition{Code: code, Name: "X"}); err == nil || errors.Is(err, ErrLocaleConflict) {
+			t.Errorf("RegisterLocale(%q) = %v, want invalid code", code, err)
+		}
+	}
+}
+
+func TestLocale_Metadata(t *testing.T) {
+	tests := []struct {
+		locale    Locale
+		native    string
+		script    string
+		direction TextDirection
+		plural    []PluralCategory
+	}{
+		{LocaleDE, "Deutsch", "Latn", LeftToRight, []PluralCategory{PluralOne, PluralOther}},
+		{LocaleAR, "العربية", "Arab", RightToLeft, []PluralCategory{PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther}},
+		{LocaleHE, "עברית", "Hebr", RightToLeft, nil},
+		{LocaleSR, "српски", "Cyrl", LeftToRight, nil},
+		{LocaleSRLatn, "srpski (latinica)", "Latn", LeftToRight, nil},
+		{LocaleENGB, "British English", "Latn", LeftToRight, []PluralCategory{PluralOne, PluralOther}},
+		{LocaleJA, "日本語", "Jpan", LeftToRight, []PluralCategory{PluralOther}},
+	}
+	for _, tt := range tests {
+		if got := tt.locale.NativeName(); got != tt.native {
+			t.Errorf("%v.NativeName() = %q, want %q", tt.locale, got, tt.native)
+		}
+		if got := tt.locale.PrimaryScript(); got != tt.script {
+			t.Errorf("%v.PrimaryScript() = %q, want %q", tt.locale, got, tt.script)
+		}
+		if got := tt.locale.Direction(); got != tt.direction {
+			t.Errorf("%v.Direction() = %v, want %v", tt.locale, got, tt.direction)
+		}
+		if tt.plural != nil && !slices.Equal(tt.locale.PluralCategories(), tt.plural) {
+			t.Errorf("%v.PluralCategories() = %v, want %v", tt.locale, tt.locale.PluralCategories(), tt.plural)
+		}
+	}
+
+	for l := range languages {
+		if l.NativeName() == l.Name() && l != LocaleEN {
+			t.Errorf("%v has no native name", l)
+		}
+		if l.PrimaryScript() == "" {
+			t.Errorf("%v has no primary script", l)
+		}
+	}
+
+	if ar := MustRegisterLocale(LocaleDefinition{Code: "ar-EG", Name: "Arabic (Egypt)"}); ar.Direction() != RightToLeft || ar.PrimaryScript() != "Arab" {
+		t.Errorf("ar-EG direction %v script %q", ar.Direction(), ar.PrimaryScript())
+	}
+}
+
+func TestRegisterLocale_Metadata(t *testing.T) {
+	hi := MustRegisterLocale(LocaleDefinition{
+		Code: "hi", Name: "Hindi", NativeName: "हिन्दी", Script: "Deva",
+		PluralCategories: []PluralCategory{PluralOne, PluralOther},
+		PluralRule: func(o PluralOperands) PluralCategory {
+			if o.I == 0 || o.I == 1 && o.F == 0 {
+				return PluralOne
+			}
+			return PluralOther
+		},
+	})
+	if hi.PrimaryScript() != "Deva" || hi.Direction() != LeftToRight {
+		t.Errorf("hi script %q direction %v", hi.PrimaryScript(), hi.Direction())
+	}
+	if PluralCategoryOf(hi, 0) != PluralOne || PluralCategoryOf(hi, 2) != PluralOther {
+		t.Error("hi plural rule not applied")
+	}
+	if in := MustRegisterLocale(LocaleDefinition{Code: "hi-IN", Name: "Hindi (India)"}); PluralCategoryOf(in, 1) != PluralOne || in.PrimaryScript() != "Deva" {
+		t.Error("hi-IN should inherit the hi rules and script")
+	}
+
+	ckb := MustRegisterLocale(LocaleDefinition{Code: "ckb", Name: "Central Kurdish", Script: "Arab"})
+	if ckb.Direction() != RightToLeft {
+		t.Error("Arabic-script locale should be right-to-left")
+	}
+	if pseudo := MustRegisterLocale(LocaleDefinition{Code: "en-XB", Name: "English (pseudo-bidi)", Direction: RightToLeft}); pseudo.Direction() != RightToLeft || pseudo.PrimaryScript() != "Latn" {
+		t.Errorf("en-XB direction %v script %q", pseudo.Direction(), pseudo.PrimaryScript())
+	}
+
+	if _, err := RegisterLocale(LocaleDefinition{Code: "sw", Name: "Swahili", PluralCategories: []PluralCategory{PluralOne}}); err == nil {
+		t.Error("categories without a rule should be rejected")
+	}
+}
@@ -0,0 +1,539 @@
+package gotrans
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrMessageArgument is returned by Message.Format when an argument is missing
+// or has a type its placeholder cannot format.
+var ErrMessageArgument = errors.New("invalid message argument")
+
+// MessageSyntaxError is returned by ParseMessage for a malformed pattern.
+type MessageSyntaxError struct {
+	Offset int // byte offset into the pattern
+	Msg    string
+}
+
+func (e *MessageSyntaxError) Error() string {
+	return fmt.Sprintf("gotrans: message syntax error at offset %d: %s", e.Offset, e.Msg)
+}
+
+// Message is a parsed ICU MessageFormat pattern. It is immutable and safe for
+// concurrent use.
+//
+// Supported syntax:
+//
+//	{name}                                  argument, formatted by its Go type
+//	{name, number}                          also: number, integer | number, percent
+//	{name, date}  {name, time}              style: short, medium, long, full
+//	{name, plural, offset:1 =0 {…} one {…} other {…}}   # is the number
+//	{name, select, female {…} other {…}}
+//
+// Apostrophes quote as in ICU: two apostrophes make a literal one and '{…}'
+// is literal text. Plural categories follow the CLDR rules of the formatting
+// locale.
+type Message struct {
+	pattern string
+	nodes   []msgNode
+}
+
+// ParseMessage parses an ICU MessageFormat pattern.
+func ParseMessage(pattern string) (*Message, error) {
+	p := &msgParser{s: pattern}
+	nodes, err := p.parseMessage(false, false)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.s) {
+		return nil, p.errorf("unmatched '}'")
+	}
+	return &Message{pattern: pattern, nodes: nodes}, nil
+}
+
+// FormatMessage parses pattern and formats it for locale l in one step.
+func FormatMessage(l Locale, pattern string, args map[string]any) (string, error) {
+	m, err := ParseMessage(pattern)
+	if err != nil {
+		return "", err
+	}
+	return m.Format(l, args)
+}
+
+// String returns the original pattern.
+func (m *Message) String() string { return m.pattern }
+
+// Format renders the message for locale l. Numbers and dates are formatted
+// with the conventions of l.
+func (m *Message) Format(l Locale, args map[string]any) (string, error) {
+	var b strings.Builder
+	f := msgFormatter{locale: l, args: args}
+	if err := f.format(&b, m.nodes, nil); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Arguments returns the names of the arguments the message references, in
+// order of first appearance.
+func (m *Message) Arguments() []string {
+	var names []string
+	seen := make(map[string]struct{})
+	var walk func(nodes []msgNode)
+	walk = func(nodes []msgNode) {
+		for _, n := range nodes {
+			var name string
+			var cases []msgCase
+			switch n := n.(type) {
+			case argNode:
+				name = n.name
+			case choiceNode:
+				name, cases = n.name, n.cases
+			default:
+				continue
+			}
+			if _, ok := seen[name]; !ok {
+				seen[name] = struct{}{}
+				names = append(names, name)
+			}
+			for _, c := range cases {
+				walk(c.nodes)
+			}
+		}
+	}
+	walk(m.nodes)
+	return names
+}
+
+// -----------------------------------------------------------------------------
+// AST
+// -----------------------------------------------------------------------------
+
+type msgNode interface{}
+
+type textNode string
+
+// poundNode is '#' inside a plural case: the plural number minus offset.
+type poundNode struct{}
+
+type argNode struct {
+	name  string
+	typ   string // "", number, date, time
+	style string
+}
+
+type msgCase struct {
+	key   string // keyword or "=N"
+	nodes []msgNode
+}
+
+// choiceNode is a plural or select argument.
+type choiceNode struct {
+	name   string
+	plural bool
+	offset float64
+	cases  []msgCase
+}
+
+// -----------------------------------------------------------------------------
+// Parser
+// -----------------------------------------------------------------------------
+
+type msgParser struct {
+	s   string
+	pos int
+}
+
+func (p *msgParser) errorf(format string, args ...any) error {
+	return &MessageSyntaxError{Offset: p.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// parseMessage parses text and arguments up to the end of input or, when
+// nested, up to (not including) the closing '}'.
+func (p *msgParser) parseMessage(nested, inPlural bool) ([]msgNode, error) {
+	var nodes []msgNode
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			nodes = append(nodes, textNode(text.String()))
+			text.Reset()
+		}
+	}
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		switch {
+		case c == '\'':
+			p.parseApostrophe(&text, inPlural)
+		case c == '{':
+			flush()
+			n, err := p.parseArgument(inPlural)
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, n)
+		case c == '}':
+			if !nested {
+				return nil, p.errorf("unmatched '}'")
+			}
+			flush()
+			return nodes, nil
+		case c == '#' && inPlural:
+			flush()
+			nodes = append(nodes, poundNode{})
+			p.pos++
+		default:
+			text.WriteByte(c)
+			p.pos++
+		}
+	}
+	if nested {
+		return nil, p.errorf("unterminated sub-message, missing '}'")
+	}
+	flush()
+	return nodes, nil
+}
+
+// parseApostrophe handles ICU quoting at an apostrophe: two apostrophes make
+// a literal one, an apostrophe before a syntax character starts quoted text up
+// to the next single apostrophe, any other apostrophe is literal.
+func (p *msgParser) parseApostrophe(text *strings.Builder, inPlural bool) {
+	p.pos++
+	if p.pos < len(p.s) && p.s[p.pos] == '\'' {
+		text.WriteByte('\'')
+		p.pos++
+		return
+	}
+	if p.pos >= len(p.s) || !(p.s[p.pos] == '{' || p.s[p.pos] == '}' || p.s[p.pos] == '|' || p.s[p.pos] == '#' && inPlural) {
+		text.WriteByte('\'')
+		return
+	}
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		p.pos++
+		if c != '\'' {
+			text.WriteByte(c)
+			continue
+		}
+		if p.pos < len(p.s) && p.s[p.pos] == '\'' {
+			text.WriteByte('\'')
+			p.pos++
+			continue
+		}
+		return
+	}
+}
+
+func (p *msgParser) skipSpace() {
+	for p.pos < len(p.s) && strings.IndexByte(" \t\r\n", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// ident reads a name or keyword: anything up to whitespace or syntax characters.
+func (p *msgParser) ident() string {
+	start := p.pos
+	for p.pos < len(p.s) && strings.IndexByte(" \t\r\n{},#'", p.s[p.pos]) < 0 {
+		p.pos++
+	}
+	return p.s[start:p.pos]
+}
+
+func (p *msgParser) expect(c byte) error {
+	if p.pos >= len(p.s) || p.s[p.pos] != c {
+		return p.errorf("expected '%c'", c)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *msgParser) parseArgument(inPlural bool) (msgNode, error) {
+	p.pos++ // '{'
+	p.skipSpace()
+	name := p.ident()
+	if name == "" {
+		return nil, p.errorf("missing argument name")
+	}
+	p.skipSpace()
+	if p.pos < len(p.s) && p.s[p.pos] == '}' {
+		p.pos++
+		return argNode{name: name}, nil
+	}
+	if err := p.expect(','); err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	typ := p.ident()
+	p.skipSpace()
+
+	switch typ {
+	case "plural", "select":
+		if err := p.expect(','); err != nil {
+			return nil, err
+		}
+		return p.parseChoice(name, typ == "plural", inPlural)
+	case "number", "date", "time":
+		style := ""
+		if p.pos < len(p.s) && p.s[p.pos] == ',' {
+			p.pos++
+			p.skipSpace()
+			style = p.ident()
+			p.skipSpace()
+		}
+		if !validStyle(typ, style) {
+			return nil, p.errorf("unsupported %s style %q", typ, style)
+		}
+		if err := p.expect('}'); err != nil {
+			return nil, err
+		}
+		return argNode{name: name, typ: typ, style: style}, nil
+	case "":
+		return nil, p.errorf("missing argument type")
+	default:
+		return nil, p.errorf("unsupported argument type %q", typ)
+	}
+}
+
+func validStyle(typ, style string) bool {
+	switch typ {
+	case "number":
+		return style == "" || style == "integer" || style == "percent"
+	default:
+		return style == "" || style == "short" || style == "medium" || style == "long" || style == "full"
+	}
+}
+
+func (p *msgParser) parseChoice(name string, plural, inPlural bool) (msgNode, error) {
+	n := choiceNode{name: name, plural: plural}
+	kind := "select"
+	if plural {
+		kind = "plural"
+	}
+	p.skipSpace()
+	if plural && strings.HasPrefix(p.s[p.pos:], "offset:") {
+		p.pos += len("offset:")
+		p.skipSpace()
+		v, err := strconv.ParseFloat(p.ident(), 64)
+		if err != nil {
+			return nil, p.errorf("invalid plural offset")
+		}
+		n.offset = v
+	}
+	seen := make(map[string]bool)
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.s) {
+			return nil, p.errorf("unterminated %s argument", kind)
+		}
+		if p.s[p.pos] == '}' {
+			p.pos++
+			break
+		}
+		key := p.ident()
+		if key == "" {
+			return nil, p.errorf("missing selector")
+		}
+		if err := validSelector(key, plural); err != nil {
+			return nil, p.errorf("%v", err)
+		}
+		if seen[key] {
+			return nil, p.errorf("duplicate selector %q", key)
+		}
+		seen[key] = true
+		p.skipSpace()
+		if err := p.expect('{'); err != nil {
+			return nil, err
+		}
+		nodes, err := p.parseMessage(true, plural || inPlural)
+		if err != nil {
+			return nil, err
+		}
+		p.pos++ // '}'
+		n.cases = append(n.cases, msgCase{key: key, nodes: nodes})
+	}
+	if !seen["other"] {
+		return nil, p.errorf("%s argument %q has no 'other' case", kind, name)
+	}
+	return n, nil
+}
+
+func validSelector(key string, plural bool) error {
+	if !plural {
+		return nil
+	}
+	if strings.HasPrefix(key, "=") {
+		if _, err := strconv.ParseFloat(key[1:], 64); err != nil {
+			return fmt.Errorf("invalid plural selector %q", key)
+		}
+		return nil
+	}
+	switch PluralCategory(key) {
+	case PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther:
+		return nil
+	}
+	return fmt.Errorf("unknown plural category %q", key)
+}
+
+// -----------------------------------------------------------------------------
+// Formatter
+// -----------------------------------------------------------------------------
+
+type msgFormatter struct {
+	locale Locale
+	args   map[string]any
+}
+
+// pluralValue is the number '#' stands for inside a plural case.
+type pluralValue struct {
+	n float64
+}
+
+func (f *msgFormatter) arg(name string) (any, error) {
+	v, ok := f.args[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: missing %q", ErrMessageArgument, name)
+	}
+	return v, nil
+}
+
+func (f *msgFormatter) format(b *strings.Builder, nodes []msgNode, pound *pluralValue) error {
+	for _, n := range nodes {
+		switch n := n.(type) {
+		case textNode:
+			b.WriteString(string(n))
+		case poundNode:
+			b.WriteString(FormatNumber(f.locale, pound.n))
+		case argNode:
+			v, err := f.arg(n.name)
+			if err != nil {
+				return err
+			}
+			s, err := f.formatArg(n, v)
+			if err != nil {
+				return err
+			}
+			b.WriteString(s)
+		case choiceNode:
+			v, err := f.arg(n.name)
+			if err != nil {
+				return err
+			}
+			if !n.plural {
+				if err := f.format(b, n.pick(fmt.Sprint(v)), pound); err != nil {
+					return err
+				}
+				continue
+			}
+			num, ok := toFloat(v)
+			if !ok {
+				return fmt.Errorf("%w: %q is %T, plural needs a number", ErrMessageArgument, n.name, v)
+			}
+			if err := f.format(b, n.pickPlural(f.locale, num), &pluralValue{num - n.offset}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (f *msgFormatter) formatArg(n argNode, v any) (string, error) {
+	switch n.typ {
+	case "number":
+		num, ok := toFloat(v)
+		if !ok {
+			return "", fmt.Errorf("%w: %q is %T, want a number", ErrMessageArgument, n.name, v)
+		}
+		switch n.style {
+		case "integer":
+			return FormatInteger(f.locale, num), nil
+		case "percent":
+			return FormatPercent(f.locale, num), nil
+		}
+		return FormatNumber(f.locale, num), nil
+	case "date", "time":
+		t, ok := v.(time.Time)
+		if !ok {
+			return "", fmt.Errorf("%w: %q is %T, want time.Time", ErrMessageArgument, n.name, v)
+		}
+		if n.typ == "date" {
+			return FormatDate(f.locale, t, DateStyle(n.style)), nil
+		}
+		return FormatTime(f.locale, t, DateStyle(n.style)), nil
+	}
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case time.Time:
+		return FormatDate(f.locale, v, DateShort) + " " + FormatTime(f.locale, v, DateShort), nil
+	case fmt.Stringer:
+		return v.String(), nil
+	}
+	if num, ok := toFloat(v); ok {
+		return FormatNumber(f.locale, num), nil
+	}
+	return fmt.Sprint(v), nil
+}
+
+// pick returns the case for key, or "other".
+func (n choiceNode) pick(key string) []msgNode {
+	var other []msgNode
+	for _, c := range n.cases {
+		if c.key == key {
+			return c.nodes
+		}
+		if c.key == "other" {
+			other = c.nodes
+		}
+	}
+	return other
+}
+
+// pickPlural tries exact "=N" matches on the number first, then the CLDR
+// category of the number minus offset.
+func (n choiceNode) pickPlural(l Locale, num float64) []msgNode {
+	for _, c := range n.cases {
+		if strings.HasPrefix(c.key, "=") {
+			if v, _ := strconv.ParseFloat(c.key[1:], 64); v == num {
+				return c.nodes
+			}
+		}
+	}
+	o, err := ParsePluralOperands(strconv.FormatFloat(num-n.offset, 'f', -1, 64))
+	if err != nil {
+		return n.pick(string(PluralOther))
+	}
+	return n.pick(string(PluralCategoryOfOperands(l, o)))
+}
+
+// toFloat converts Go numeric types to float64.
+func toFloat(v any) (float64, bool) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
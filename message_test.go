@@ -0,0 +1,113 @@
+package gotrans
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatMessage(t *testing.T) {
+	date := time.Date(2024, time.March, 5, 14, 7, 9, 0, time.UTC)
+	cases := []struct {
+		name    string
+		locale  Locale
+		pattern string
+		args    map[string]any
+		want    string
+	}{
+		{"simple", LocaleEN, "Hello, {name}!", map[string]any{"name": "Ann"}, "Hello, Ann!"},
+		{"number en", LocaleEN, "{n, number}", map[string]any{"n": 1234567.891}, "1,234,567.891"},
+		{"number de", LocaleDE, "{n, number}", map[string]any{"n": 1234.5}, "1.234,5"},
+		{"number fr", LocaleFR, "{n}", map[string]any{"n": 1234}, "1\u202f234"},
+		{"number es min grouping", LocaleES, "{a} {b}", map[string]any{"a": 1234, "b": 12345}, "1234 12.345"},
+		{"integer", LocaleEN, "{n, number, integer}", map[string]any{"n": 2.6}, "3"},
+		{"percent", LocaleDE, "{n, number, percent}", map[string]any{"n": 0.25}, "25\u00a0%"},
+		{"date short", LocaleEN, "{d, date, short}", map[string]any{"d": date}, "3/5/24"},
+		{"date medium", LocaleDE, "{d, date}", map[string]any{"d": date}, "05.03.2024"},
+		{"date ja", LocaleJA, "{d, date, long}", map[string]any{"d": date}, "2024/03/05"},
+		{"time", LocaleEN, "{d, time, short}", map[string]any{"d": date}, "2:07 PM"},
+		{"time ru", LocaleRU, "{d, time}", map[string]any{"d": date}, "14:07:09"},
+		{
+			"plural ru", LocaleRU,
+			"{n, plural, one {# товар} few {# товара} many {# товаров} other {# товара}}",
+			map[string]any{"n": 22}, "22 товара",
+		},
+		{
+			"plural exact and offset", LocaleEN,
+			"{n, plural, offset:1 =0 {nobody} =1 {{who}} one {{who} and # other} other {{who} and # others}}",
+			map[string]any{"n": 3, "who": "Ann"}, "Ann and 2 others",
+		},
+		{"plural decimal", LocaleEN, "{n, plural, one {# item} other {# items}}", map[string]any{"n": 1.5}, "1.5 items"},
+		{
+			"select with nested plural", LocaleEN,
+			"{g, select, female {She has {n, plural, one {# cat} other {# cats}}} other {They have # cats}}",
+			map[string]any{"g": "female", "n": 1}, "She has 1 cat",
+		},
+		{"select other", LocaleEN, "{g, select, female {she} other {they}}", map[string]any{"g": "x"}, "they"},
+		{"quoting", LocaleEN, "It''s '{literal}' and '#' {n, plural, other {'#' is #}}", map[string]any{"n": 2}, "It's {literal} and '#' # is 2"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := FormatMessage(c.locale, c.pattern, c.args)
+			require.NoError(t, err)
+			require.Equal(t, c.want, got)
+		})
+	}
+}
+
+func TestParseMessage_SyntaxErrors(t *testing.T) {
+	for _, pattern := range []string{
+		"{name",
+		"oops}",
+		"{}",
+		"{n, plural, one {x}}",
+		"{n, plural, other {x}",
+		"{n, plural, lots {x} other {y}}",
+		"{n, select, a {x} a {y} other {z}}",
+		"{n, number, currency}",
+		"{n, spellout}",
+	} {
+		_, err := ParseMessage(pattern)
+		var se *MessageSyntaxError
+		require.True(t, errors.As(err, &se), "%q: %v", pattern, err)
+	}
+}
+
+func TestMessage_FormatArgumentErrors(t *testing.T) {
+	m, err := ParseMessage("{n, plural, other {#}} {d, date}")
+	require.NoError(t, err)
+	require.Equal(t, []string{"n", "d"}, m.Arguments())
+
+	_, err = m.Format(LocaleEN, map[string]any{"d": time.Now()})
+	require.ErrorIs(t, err, ErrMessageArgument)
+	_, err = m.Format(LocaleEN, map[string]any{"n": "many", "d": time.Now()})
+	require.ErrorIs(t, err, ErrMessageArgument)
+	_, err = m.Format(LocaleEN, map[string]any{"n": 1, "d": "today"})
+	require.ErrorIs(t, err, ErrMessageArgument)
+}
+
+func TestMessageFormatValidator_RejectsOnSave(t *testing.T) {
+	repo := &mockRepo{}
+	trans := NewTranslatorWithOptions(TranslatorOptions[Parameter]{
+		Repository: repo,
+		Validators: []TranslationValidator{MessageFormatValidator{Fields: []string{"name"}}},
+	})
+
+	err := trans.SaveTranslations(context.Background(), []Parameter{
+		{ID: 1, locale: LocaleEN, Name: "{n, plural, one {# item} other {# items}}", Description: "{not validated"},
+		{ID: 2, locale: LocaleFR, Name: "{n, plural, one {# article}"},
+	})
+	var se *MessageSyntaxError
+	require.ErrorAs(t, err, &se)
+	require.Contains(t, err.Error(), `parameter 2 field "name" (fr)`)
+	require.Empty(t, repo.saved, "nothing is written when validation fails")
+
+	err = trans.SaveTranslations(context.Background(), []Parameter{
+		{ID: 1, locale: LocaleEN, Name: "{n, plural, one {# item} other {# items}}", Description: "{not validated"},
+	})
+	require.NoError(t, err)
+	require.Len(t, repo.saved, 2)
+}
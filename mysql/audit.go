@@ -0,0 +1,45 @@
+package mysql
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ivan-gorbushko/gotrans"
+	"github.com/jmoiron/sqlx"
+)
+
+type auditSink struct {
+	db *sqlx.DB
+}
+
+var _ gotrans.AuditSink = (*auditSink)(nil)
+
+// NewAuditSink returns a gotrans.AuditSink that inserts entries into the
+// translation_audit table. Keys, entity IDs and fields are stored as JSON
+// arrays; locale is NULL for a delete across all locales.
+func NewAuditSink(db *sqlx.DB) gotrans.AuditSink {
+	return &auditSink{db: db}
+}
+
+func (s *auditSink) WriteAudit(ctx context.Context, entry gotrans.AuditEntry) error {
+	const op = "auditSink.WriteAudit"
+	keys, err := json.Marshal(entry.Keys)
+	if err != nil {
+		return wrapError(op, entry.Entity, entry.Locale, entry.EntityIDs, err)
+	}
+	ids, err := json.Marshal(entry.EntityIDs)
+	if err != nil {
+		return wrapError(op, entry.Entity, entry.Locale, entry.EntityIDs, err)
+	}
+	fields, err := json.Marshal(entry.Fields)
+	if err != nil {
+		return wrapError(op, entry.Entity, entry.Locale, entry.EntityIDs, err)
+	}
+
+	_, err = s.db.ExecContext(ctx, s.db.Rebind(`INSERT INTO translation_audit
+		(action, actor, request_id, entity, locale, keys_json, entity_ids_json, fields_json, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+		string(entry.Action), entry.Actor, entry.RequestID, entry.Entity, entry.Locale,
+		string(keys), string(ids), string(fields), entry.At.UTC())
+	return wrapError(op, entry.Entity, entry.Locale, entry.EntityIDs, err)
+}
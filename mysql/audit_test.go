@@ -0,0 +1,56 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ivan-gorbushko/gotrans"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditSink(t *testing.T) {
+	db := newTestDB(t)
+	_, err := db.Exec(`
+		CREATE TABLE translation_audit (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			action TEXT,
+			actor TEXT,
+			request_id TEXT,
+			entity TEXT,
+			locale TEXT NULL,
+			keys_json TEXT,
+			entity_ids_json TEXT,
+			fields_json TEXT,
+			created_at DATETIME
+		)`)
+	require.NoError(t, err)
+
+	repo := gotrans.NewAuditRepository(NewTranslationRepository(db), NewAuditSink(db), gotrans.AuditOptions{})
+	ctx := gotrans.WithRequestID(gotrans.WithActor(context.Background(), "alice"), "req-9")
+	require.NoError(t, repo.MassCreateOrUpdate(ctx, gotrans.LocaleEN, []gotrans.Translation{
+		{Entity: "product", EntityID: 1, Field: "title", Locale: gotrans.LocaleEN, Value: "Cell phone"},
+	}))
+	require.NoError(t, repo.MassDelete(ctx, gotrans.LocaleNone, "product", []int{2}, nil))
+
+	var rows []struct {
+		Action    string    `db:"action"`
+		Actor     string    `db:"actor"`
+		RequestID string    `db:"request_id"`
+		Locale    *string   `db:"locale"`
+		Keys      string    `db:"keys_json"`
+		EntityIDs string    `db:"entity_ids_json"`
+		CreatedAt time.Time `db:"created_at"`
+	}
+	require.NoError(t, db.Select(&rows, `SELECT action, actor, request_id, locale, keys_json, entity_ids_json, created_at FROM translation_audit ORDER BY id`))
+	require.Len(t, rows, 2)
+	require.Equal(t, "save", rows[0].Action)
+	require.Equal(t, "alice", rows[0].Actor)
+	require.Equal(t, "req-9", rows[0].RequestID)
+	require.Equal(t, "en", *rows[0].Locale)
+	require.JSONEq(t, `[{"entity":"product","entity_id":1,"field":"title","locale":"en"}]`, rows[0].Keys)
+	require.WithinDuration(t, time.Now(), rows[0].CreatedAt, time.Minute)
+	require.Equal(t, "delete", rows[1].Action)
+	require.Nil(t, rows[1].Locale, "a delete across all locales has no locale")
+	require.Equal(t, "[2]", rows[1].EntityIDs)
+}
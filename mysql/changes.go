@@ -0,0 +1,198 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ivan-gorbushko/gotrans"
+	"github.com/jmoiron/sqlx"
+)
+
+// Tombstone is a row of the translation_tombstones table.
+type Tombstone struct {
+	ID        int64     `db:"id"`
+	Entity    string    `db:"entity"`
+	EntityID  int       `db:"entity_id"`
+	Field     string    `db:"field"`
+	Locale    string    `db:"locale"`
+	DeletedAt time.Time `db:"deleted_at"`
+	ChangeSeq int64     `db:"change_seq"`
+}
+
+// defaultChangesLimit applies when Changes is called with a limit <= 0.
+const defaultChangesLimit = 1000
+
+// Changes needs Options.Timestamps. Every timestamped write takes the next
+// value of translation_change_seq in its transaction and stamps its rows and
+// tombstones with it. The counter row stays locked until commit, so sequence
+// order is commit order and a transaction still open when a consumer syncs
+// shows up after the cursor once it commits. Cursors are
+// "<sequence>.<tombstone ID>.<row ID>": the last sequence read and, within
+// it, the last tombstone and row.
+func (t *translationRepository) Changes(ctx context.Context, entity, cursor string, limit int) ([]gotrans.Change, string, error) {
+	const op = "translationRepository.Changes"
+	if !t.timestamps {
+		return nil, cursor, wrapError(op, entity, gotrans.LocaleNone, nil, errors.New("mysql: Changes needs Options.Timestamps"))
+	}
+	pos, err := parseChangeCursor(cursor)
+	if err != nil {
+		return nil, cursor, wrapError(op, entity, gotrans.LocaleNone, nil, err)
+	}
+	if limit <= 0 {
+		limit = defaultChangesLimit
+	}
+
+	filter := ""
+	if entity != "" {
+		filter = " AND entity = ?"
+	}
+	args := func(afterID int64) []any {
+		if entity != "" {
+			return []any{pos.seq, pos.seq, afterID, entity, limit}
+		}
+		return []any{pos.seq, pos.seq, afterID, limit}
+	}
+	var rows []Translation
+	err = t.db.SelectContext(ctx, &rows, t.db.Rebind(`SELECT `+t.columns("")+` FROM translations
+		WHERE (change_seq > ? OR change_seq = ? AND id > ?)`+filter+` ORDER BY change_seq, id LIMIT ?`), args(pos.rowID)...)
+	if err != nil {
+		return nil, cursor, wrapError(op, entity, gotrans.LocaleNone, nil, err)
+	}
+	var tombstones []Tombstone
+	err = t.db.SelectContext(ctx, &tombstones, t.db.Rebind(`SELECT id, entity, entity_id, field, locale, deleted_at, change_seq
+		FROM translation_tombstones WHERE (change_seq > ? OR change_seq = ? AND id > ?)`+filter+`
+		ORDER BY change_seq, id LIMIT ?`), args(pos.tombstoneID)...)
+	if err != nil {
+		return nil, cursor, wrapError(op, entity, gotrans.LocaleNone, nil, err)
+	}
+
+	// Merge both lists by sequence, tombstones first on ties since a
+	// transaction deletes before it inserts. Each list holds limit entries
+	// unless it is exhausted, so nothing is skipped when one of them runs
+	// out first.
+	advance := func(seq int64) {
+		if seq > pos.seq {
+			pos = changeCursor{seq: seq}
+		}
+	}
+	changes := make([]gotrans.Change, 0, min(limit, len(rows)+len(tombstones)))
+	i, j := 0, 0
+	for len(changes) < limit && (i < len(rows) || j < len(tombstones)) {
+		if j < len(tombstones) && (i == len(rows) || tombstones[j].ChangeSeq <= rows[i].ChangeSeq) {
+			ts := tombstones[j]
+			j++
+			advance(ts.ChangeSeq)
+			pos.tombstoneID = ts.ID
+			locale, _ := t.resolveLocale(ts.Locale)
+			changes = append(changes, gotrans.Change{Deleted: true, Translation: gotrans.Translation{
+				Entity: ts.Entity, EntityID: ts.EntityID, Field: ts.Field, Locale: locale, UpdatedAt: ts.DeletedAt,
+			}})
+			continue
+		}
+		mt := rows[i]
+		i++
+		advance(mt.ChangeSeq)
+		pos.rowID = int64(mt.ID)
+		tr, ok, err := t.toTranslateModel(mt)
+		if err != nil {
+			return nil, cursor, wrapError(op, entity, gotrans.LocaleNone, []int{mt.EntityID}, err)
+		}
+		if ok {
+			changes = append(changes, gotrans.Change{Translation: tr})
+		}
+	}
+	return changes, pos.String(), nil
+}
+
+// PruneTombstones deletes the tombstones of deletions before before. Run it
+// with a margin larger than the longest time a consumer may go without
+// syncing; one that syncs later misses those deletions.
+func (t *translationRepository) PruneTombstones(ctx context.Context, before time.Time) (int, error) {
+	const op = "translationRepository.PruneTombstones"
+	res, err := t.db.ExecContext(ctx, t.db.Rebind(`DELETE FROM translation_tombstones WHERE deleted_at < ?`), before.UTC())
+	if err != nil {
+		return 0, wrapError(op, "", gotrans.LocaleNone, nil, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, wrapError(op, "", gotrans.LocaleNone, nil, err)
+	}
+	return int(n), nil
+}
+
+// changeCursor is a position in the change feed.
+type changeCursor struct {
+	seq         int64 // last sequence read
+	tombstoneID int64 // last tombstone read within seq
+	rowID       int64 // last row read within seq
+}
+
+func (c changeCursor) String() string {
+	return strconv.FormatInt(c.seq, 10) + "." + strconv.FormatInt(c.tombstoneID, 10) + "." + strconv.FormatInt(c.rowID, 10)
+}
+
+func parseChangeCursor(cursor string) (changeCursor, error) {
+	if cursor == "" {
+		return changeCursor{}, nil
+	}
+	parts := strings.Split(cursor, ".")
+	if len(parts) != 3 {
+		return changeCursor{}, fmt.Errorf("%w %q", gotrans.ErrInvalidCursor, cursor)
+	}
+	var n [3]int64
+	for i, p := range parts {
+		v, err := strconv.ParseInt(p, 10, 64)
+		if err != nil || v < 0 {
+			return changeCursor{}, fmt.Errorf("%w %q", gotrans.ErrInvalidCursor, cursor)
+		}
+		n[i] = v
+	}
+	return changeCursor{seq: n[0], tombstoneID: n[1], rowID: n[2]}, nil
+}
+
+// nextChangeSeq takes the next change sequence for the transaction. The
+// counter row stays locked until tx ends, so timestamped writes commit one at
+// a time, in sequence order.
+func nextChangeSeq(ctx context.Context, tx *sqlx.Tx) (int64, error) {
+	res, err := tx.ExecContext(ctx, `UPDATE translation_change_seq SET seq = seq + 1`)
+	if err != nil {
+		return 0, fmt.Errorf("next change sequence: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n != 1 {
+		return 0, fmt.Errorf("next change sequence: translation_change_seq has %d rows, want 1", n)
+	}
+	var seq int64
+	if err = tx.GetContext(ctx, &seq, `SELECT seq FROM translation_change_seq`); err != nil {
+		return 0, fmt.Errorf("next change sequence: %w", err)
+	}
+	return seq, nil
+}
+
+// insertTombstones records the deletion of rows at at, in change sequence seq.
+func insertTombstones(ctx context.Context, tx *sqlx.Tx, rows []Tombstone, at time.Time, seq int64) error {
+	const insertBatchSize = 500 // 500 rows × 6 cols = 3000 params, safe for MySQL and SQLite
+	for start := 0; start < len(rows); start += insertBatchSize {
+		end := min(start+insertBatchSize, len(rows))
+		placeholders := make([]string, 0, end-start)
+		args := make([]any, 0, (end-start)*6)
+		for _, r := range rows[start:end] {
+			placeholders = append(placeholders, "(?, ?, ?, ?, ?, ?)")
+			args = append(args, r.Entity, r.EntityID, r.Field, r.Locale, at, seq)
+		}
+		query := "INSERT INTO translation_tombstones (entity, entity_id, field, locale, deleted_at, change_seq) VALUES " +
+			strings.Join(placeholders, ", ")
+		if _, err := tx.ExecContext(ctx, tx.Rebind(query), args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// now returns the time stored for a write, at the precision of DATETIME(6).
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
@@ -0,0 +1,157 @@
+package mysql
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ivan-gorbushko/gotrans"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+)
+
+// addChangeTables adds what Options.Timestamps needs to a newTestDB schema.
+func addChangeTables(t *testing.T, db *sqlx.DB) {
+	t.Helper()
+	for _, stmt := range []string{
+		`ALTER TABLE translations ADD COLUMN created_at DATETIME`,
+		`ALTER TABLE translations ADD COLUMN updated_at DATETIME`,
+		`ALTER TABLE translations ADD COLUMN change_seq INTEGER NOT NULL DEFAULT 0`,
+		`UPDATE translations SET created_at = '2020-01-01 00:00:00', updated_at = '2020-01-01 00:00:00'`,
+		`CREATE TABLE translation_tombstones (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			entity TEXT,
+			entity_id INTEGER,
+			field TEXT,
+			locale TEXT,
+			deleted_at DATETIME,
+			change_seq INTEGER NOT NULL DEFAULT 0
+		)`,
+		`CREATE TABLE translation_change_seq (seq INTEGER NOT NULL)`,
+		`INSERT INTO translation_change_seq (seq) VALUES (0)`,
+	} {
+		_, err := db.Exec(stmt)
+		require.NoError(t, err)
+	}
+}
+
+func TestRepository_Changes(t *testing.T) {
+	db := newTestDB(t)
+	addChangeTables(t, db)
+	repo := NewTranslationRepositoryWithOptions(db, Options{Timestamps: true})
+	ctx := context.Background()
+
+	changes, cursor, err := repo.Changes(ctx, "product", "", 0)
+	require.NoError(t, err)
+	require.Len(t, changes, 4, "initial sync reads everything")
+	_, _, err = repo.Changes(ctx, "", "bogus", 0)
+	require.ErrorIs(t, err, gotrans.ErrInvalidCursor)
+
+	start := time.Now().Add(-time.Second)
+	require.NoError(t, repo.MassCreateOrUpdate(ctx, gotrans.LocaleEN, []gotrans.Translation{
+		{Entity: "product", EntityID: 1, Field: "title", Locale: gotrans.LocaleEN, Value: "Mobile"},
+		{Entity: "product", EntityID: 3, Field: "title", Locale: gotrans.LocaleEN, Value: "Laptop"},
+	}))
+	require.NoError(t, repo.MassDelete(ctx, gotrans.LocaleNone, "product", []int{2}, nil))
+
+	got, err := repo.GetTranslations(ctx, gotrans.LocaleEN, "product", []int{1})
+	require.NoError(t, err)
+	require.Equal(t, 2020, got[0].CreatedAt.Year(), "an update keeps created_at")
+	require.True(t, got[0].UpdatedAt.After(start))
+
+	changes, cursor, err = repo.Changes(ctx, "product", cursor, 2)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	require.Equal(t, "Mobile", changes[0].Value)
+	require.Equal(t, "Laptop", changes[1].Value)
+	require.Equal(t, changes[1].CreatedAt, changes[1].UpdatedAt, "a new row is created when updated")
+
+	changes, cursor, err = repo.Changes(ctx, "product", cursor, 2)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.True(t, changes[0].Deleted)
+	require.Equal(t, gotrans.TranslationKey{Entity: "product", EntityID: 2, Field: "title", Locale: gotrans.LocalePTBR}, changes[0].Key())
+
+	changes, next, err := repo.Changes(ctx, "product", cursor, 2)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+	require.Equal(t, cursor, next)
+
+	n, err := repo.PruneTombstones(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+}
+
+func TestRepository_ChangesInterleavedTransactions(t *testing.T) {
+	// A file database, so that the transactions and the consumer use
+	// separate connections.
+	db, err := sqlx.Open("sqlite3", "file:"+filepath.Join(t.TempDir(), "changes.db")+"?_journal_mode=WAL&_busy_timeout=5000")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	_, err = db.Exec(`CREATE TABLE translations (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		entity TEXT,
+		entity_id INTEGER,
+		field TEXT,
+		locale TEXT,
+		value TEXT,
+		UNIQUE(entity, entity_id, field, locale)
+	)`)
+	require.NoError(t, err)
+	addChangeTables(t, db)
+	repo := NewTranslationRepositoryWithOptions(db, Options{Timestamps: true})
+	ctx := context.Background()
+	write := func(id int, value string) error {
+		return repo.MassCreateOrUpdate(ctx, gotrans.LocaleEN, []gotrans.Translation{
+			{Entity: "product", EntityID: id, Field: "title", Locale: gotrans.LocaleEN, Value: value},
+		})
+	}
+
+	// Transaction A takes its sequence and writes its row, then stays open.
+	txA, err := db.BeginTxx(ctx, nil)
+	require.NoError(t, err)
+	defer txA.Rollback() //nolint:errcheck
+	seqA, err := nextChangeSeq(ctx, txA)
+	require.NoError(t, err)
+	_, err = txA.Exec(`INSERT INTO translations (entity, entity_id, field, locale, value, created_at, updated_at, change_seq)
+		VALUES ('product', 1, 'title', 'en', 'Phone', ?, ?, ?)`, now(), now(), seqA)
+	require.NoError(t, err)
+
+	// Transaction B starts while A is open.
+	doneB := make(chan error, 1)
+	go func() { doneB <- write(2, "Tablet") }()
+	select {
+	case err = <-doneB:
+		t.Fatalf("B committed before A: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// A consumer syncing now sees neither.
+	changes, cursor, err := repo.Changes(ctx, "product", "", 0)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	require.NoError(t, txA.Commit())
+	require.NoError(t, <-doneB)
+
+	changes, cursor, err = repo.Changes(ctx, "product", cursor, 1)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.Equal(t, "Phone", changes[0].Value, "A committed first")
+	changes, _, err = repo.Changes(ctx, "product", cursor, 0)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.Equal(t, "Tablet", changes[0].Value)
+}
+
+func TestParseChangeCursor(t *testing.T) {
+	c, err := parseChangeCursor("7.3.12")
+	require.NoError(t, err)
+	require.Equal(t, changeCursor{seq: 7, tombstoneID: 3, rowID: 12}, c)
+	require.Equal(t, "7.3.12", c.String())
+	for _, bad := range []string{"12.3", "7.3.x", "7.-1.2", "1.2.3.4"} {
+		_, err = parseChangeCursor(bad)
+		require.ErrorIs(t, err, gotrans.ErrInvalidCursor, bad)
+	}
+}
@@ -0,0 +1,123 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ivan-gorbushko/gotrans"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Versions(t *testing.T) {
+	db := newTestDB(t)
+	_, err := db.Exec(`ALTER TABLE translations ADD COLUMN version INTEGER NOT NULL DEFAULT 1`)
+	require.NoError(t, err)
+	repo := NewTranslationRepositoryWithOptions(db, Options{Versions: true})
+	ctx := context.Background()
+	conditional := gotrans.WithVersionCheck(ctx)
+
+	got, err := repo.GetTranslations(ctx, gotrans.LocaleEN, "product", []int{1})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), got[0].Version, "existing rows start at version 1")
+
+	phone := gotrans.Translation{Entity: "product", EntityID: 1, Field: "title", Locale: gotrans.LocaleEN, Value: "Mobile", Version: 1}
+	require.NoError(t, repo.MassCreateOrUpdate(conditional, gotrans.LocaleEN, []gotrans.Translation{phone}))
+	got, err = repo.GetTranslations(ctx, gotrans.LocaleEN, "product", []int{1})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), got[0].Version)
+
+	phone.Value = "Cellphone"
+	tablet := gotrans.Translation{Entity: "product", EntityID: 2, Field: "title", Locale: gotrans.LocaleEN, Value: "Tablet"}
+	err = repo.MassCreateOrUpdate(conditional, gotrans.LocaleEN, []gotrans.Translation{phone, tablet})
+	var conflict *gotrans.VersionConflictError
+	require.ErrorAs(t, err, &conflict)
+	require.Equal(t, gotrans.KindConflict, gotrans.KindOf(err))
+	require.Equal(t, []gotrans.VersionConflict{{TranslationKey: phone.Key(), Expected: 1, Actual: 2}}, conflict.Conflicts)
+	got, err = repo.GetTranslations(ctx, gotrans.LocaleEN, "product", []int{1, 2})
+	require.NoError(t, err)
+	require.Len(t, got, 1, "nothing is written on conflict")
+
+	require.NoError(t, repo.MassCreateOrUpdate(ctx, gotrans.LocaleEN, []gotrans.Translation{phone}), "unconditional saves always win")
+	got, err = repo.GetTranslations(ctx, gotrans.LocaleEN, "product", []int{1})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), got[0].Version)
+}
+
+type versionedPage struct {
+	gotrans.VersionTracking
+	ID    int
+	Title string
+	Body  string
+}
+
+func (p versionedPage) TranslationEntityID() int                { return p.ID }
+func (p versionedPage) TranslationEntityName() string           { return "versioned_page" }
+func (p versionedPage) TranslationEntityLocale() gotrans.Locale { return gotrans.LocaleDE }
+func (p versionedPage) TranslatableFields() map[string]string {
+	return map[string]string{"Title": "title", "Body": "body"}
+}
+
+func TestRepository_ConditionalDelete(t *testing.T) {
+	db := newTestDB(t)
+	_, err := db.Exec(`ALTER TABLE translations ADD COLUMN version INTEGER NOT NULL DEFAULT 1`)
+	require.NoError(t, err)
+	repo := NewTranslationRepositoryWithOptions(db, Options{Versions: true})
+	trans := gotrans.NewTranslatorWithOptions(gotrans.TranslatorOptions[*versionedPage]{Repository: repo, EmptyValues: gotrans.EmptyValueDelete})
+	ctx := context.Background()
+	conditional := gotrans.SaveOptions{Conditional: true, Fields: []string{"body"}}
+
+	require.NoError(t, trans.SaveTranslations(ctx, []*versionedPage{{ID: 1, Title: "Titel", Body: "Text"}}))
+	load := func() *versionedPage {
+		got, err := trans.LoadTranslations(ctx, []*versionedPage{{ID: 1}})
+		require.NoError(t, err)
+		return got[0]
+	}
+	alice, bob := load(), load()
+
+	bob.Body = "Neuer Text"
+	require.NoError(t, trans.SaveTranslationsWithOptions(ctx, []*versionedPage{bob}, conditional))
+
+	alice.Body = ""
+	err = trans.SaveTranslationsWithOptions(ctx, []*versionedPage{alice}, conditional)
+	var conflict *gotrans.VersionConflictError
+	require.ErrorAs(t, err, &conflict)
+	require.Equal(t, []string{"body"}, conflict.Fields())
+	require.Equal(t, "Neuer Text", load().Body, "a conflicting delete removes nothing")
+
+	alice = load()
+	alice.Body = ""
+	require.NoError(t, trans.SaveTranslationsWithOptions(ctx, []*versionedPage{alice}, conditional))
+	require.Empty(t, load().Body)
+	require.Equal(t, "Titel", load().Title)
+}
+
+func TestRepository_ConditionalSaveWritesNothingWhenStale(t *testing.T) {
+	db := newTestDB(t)
+	_, err := db.Exec(`ALTER TABLE translations ADD COLUMN version INTEGER NOT NULL DEFAULT 1`)
+	require.NoError(t, err)
+	repo := NewTranslationRepositoryWithOptions(db, Options{Versions: true})
+	trans := gotrans.NewTranslatorWithOptions(gotrans.TranslatorOptions[*versionedPage]{Repository: repo, EmptyValues: gotrans.EmptyValueDelete})
+	ctx := context.Background()
+
+	require.NoError(t, trans.SaveTranslations(ctx, []*versionedPage{{ID: 1, Title: "Titel", Body: "Text"}}))
+	load := func() *versionedPage {
+		got, err := trans.LoadTranslations(ctx, []*versionedPage{{ID: 1}})
+		require.NoError(t, err)
+		return got[0]
+	}
+	alice, bob := load(), load()
+
+	bob.Body = "Neuer Text"
+	require.NoError(t, trans.SaveTranslationsWithOptions(ctx, []*versionedPage{bob}, gotrans.SaveOptions{Conditional: true, Fields: []string{"body"}}))
+
+	// The title write is current, the body delete is stale: the delete would
+	// only fail after the write was committed without the up-front check.
+	alice.Title, alice.Body = "Neuer Titel", ""
+	err = trans.SaveTranslationsWithOptions(ctx, []*versionedPage{alice}, gotrans.SaveOptions{Conditional: true})
+	var conflict *gotrans.VersionConflictError
+	require.ErrorAs(t, err, &conflict)
+	require.Equal(t, []string{"body"}, conflict.Fields())
+	got := load()
+	require.Equal(t, "Titel", got.Title, "the current title is not written either")
+	require.Equal(t, "Neuer Text", got.Body)
+}
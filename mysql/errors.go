@@ -0,0 +1,131 @@
+package mysql
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"syscall"
+
+	"github.com/ivan-gorbushko/gotrans"
+)
+
+// MySQL server error numbers, see
+// https://dev.mysql.com/doc/mysql-errors/8.0/en/server-error-reference.html.
+var mysqlErrorKinds = map[uint64]gotrans.ErrorKind{
+	1022: gotrans.KindConflict,   // ER_DUP_KEY
+	1062: gotrans.KindConflict,   // ER_DUP_ENTRY
+	1451: gotrans.KindConflict,   // ER_ROW_IS_REFERENCED_2
+	1452: gotrans.KindConflict,   // ER_NO_REFERENCED_ROW_2
+	1048: gotrans.KindValidation, // ER_BAD_NULL_ERROR
+	1366: gotrans.KindValidation, // ER_TRUNCATED_WRONG_VALUE_FOR_FIELD
+	1406: gotrans.KindValidation, // ER_DATA_TOO_LONG
+	1146: gotrans.KindNotFound,   // ER_NO_SUCH_TABLE
+	1205: gotrans.KindTransient,  // ER_LOCK_WAIT_TIMEOUT
+	1213: gotrans.KindTransient,  // ER_LOCK_DEADLOCK
+	1040: gotrans.KindTransient,  // ER_CON_COUNT_ERROR
+	3024: gotrans.KindTransient,  // ER_QUERY_TIMEOUT
+	1317: gotrans.KindCanceled,   // ER_QUERY_INTERRUPTED
+	2006: gotrans.KindTransient,  // CR_SERVER_GONE_ERROR
+	2013: gotrans.KindTransient,  // CR_SERVER_LOST
+}
+
+// SQLite primary result codes, see https://www.sqlite.org/rescode.html.
+var sqliteErrorKinds = map[uint64]gotrans.ErrorKind{
+	5:  gotrans.KindTransient,  // SQLITE_BUSY
+	6:  gotrans.KindTransient,  // SQLITE_LOCKED
+	9:  gotrans.KindCanceled,   // SQLITE_INTERRUPT
+	18: gotrans.KindValidation, // SQLITE_TOOBIG
+	19: gotrans.KindConflict,   // SQLITE_CONSTRAINT
+}
+
+// wrapError returns err as a *gotrans.Error for op with the driver error
+// classified. It returns nil for a nil err.
+func wrapError(op, entity string, locale gotrans.Locale, ids []int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &gotrans.Error{Op: op, Entity: entity, Locale: locale, IDs: ids, Kind: classify(err), Err: err}
+}
+
+// classify maps driver errors to a gotrans.ErrorKind. Drivers are recognized
+// by shape rather than imported: go-sql-driver/mysql's *MySQLError (Number),
+// mattn/go-sqlite3's Error (Code) and any error with a SQLSTATE (SQLState()).
+func classify(err error) gotrans.ErrorKind {
+	if kind := gotrans.KindOf(err); kind != gotrans.KindUnknown {
+		return kind
+	}
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if kind, ok := classifyDriverError(e); ok {
+			return kind
+		}
+	}
+	switch {
+	case errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, syscall.ECONNRESET),
+		errors.Is(err, syscall.ECONNREFUSED), errors.Is(err, syscall.EPIPE):
+		return gotrans.KindTransient
+	}
+	return gotrans.KindUnknown
+}
+
+func classifyDriverError(err error) (gotrans.ErrorKind, bool) {
+	if s, ok := err.(interface{ SQLState() string }); ok {
+		if kind, ok := sqlStateKind(s.SQLState()); ok {
+			return kind, true
+		}
+	}
+	v := reflect.ValueOf(err)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return gotrans.KindUnknown, false
+	}
+	switch typ := v.Type(); {
+	case typ.Name() == "MySQLError":
+		if n, ok := uintField(v, "Number"); ok {
+			kind, ok := mysqlErrorKinds[n]
+			return kind, ok
+		}
+	case typ.Name() == "Error" && typ.PkgPath() == "github.com/mattn/go-sqlite3":
+		if n, ok := uintField(v, "Code"); ok {
+			kind, ok := sqliteErrorKinds[n]
+			return kind, ok
+		}
+	}
+	return gotrans.KindUnknown, false
+}
+
+// sqlStateKind classifies a five-character SQLSTATE by its class.
+func sqlStateKind(state string) (gotrans.ErrorKind, bool) {
+	if len(state) != 5 {
+		return gotrans.KindUnknown, false
+	}
+	switch {
+	case state == "40001", state == "40P01": // serialization failure, deadlock
+		return gotrans.KindTransient, true
+	case state == "57014": // query canceled
+		return gotrans.KindCanceled, true
+	case state[:2] == "23": // integrity constraint violation
+		return gotrans.KindConflict, true
+	case state[:2] == "22": // data exception
+		return gotrans.KindValidation, true
+	case state[:2] == "08": // connection exception
+		return gotrans.KindTransient, true
+	case state == "42P01", state == "42S02": // undefined table
+		return gotrans.KindNotFound, true
+	}
+	return gotrans.KindUnknown, false
+}
+
+func uintField(v reflect.Value, name string) (uint64, bool) {
+	f := v.FieldByName(name)
+	switch f.Kind() {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint:
+		return f.Uint(), true
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Int:
+		if f.Int() >= 0 {
+			return uint64(f.Int()), true
+		}
+	}
+	return 0, false
+}
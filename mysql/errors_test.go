@@ -0,0 +1,60 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ivan-gorbushko/gotrans"
+	"github.com/stretchr/testify/require"
+)
+
+// MySQLError has the shape of go-sql-driver/mysql's error type.
+type MySQLError struct {
+	Number  uint16
+	Message string
+}
+
+func (e *MySQLError) Error() string { return fmt.Sprintf("Error %d: %s", e.Number, e.Message) }
+
+type pgError struct{ code string }
+
+func (e pgError) Error() string    { return "pg: " + e.code }
+func (e pgError) SQLState() string { return e.code }
+
+func TestClassify(t *testing.T) {
+	cases := []struct {
+		err  error
+		want gotrans.ErrorKind
+	}{
+		{&MySQLError{Number: 1062}, gotrans.KindConflict},
+		{fmt.Errorf("exec: %w", &MySQLError{Number: 1213}), gotrans.KindTransient},
+		{&MySQLError{Number: 1406}, gotrans.KindValidation},
+		{&MySQLError{Number: 1317}, gotrans.KindCanceled},
+		{&MySQLError{Number: 1064}, gotrans.KindUnknown},
+		{pgError{"23505"}, gotrans.KindConflict},
+		{pgError{"40P01"}, gotrans.KindTransient},
+		{sql.ErrNoRows, gotrans.KindNotFound},
+		{context.Canceled, gotrans.KindCanceled},
+		{context.DeadlineExceeded, gotrans.KindTransient},
+		{errors.New("boom"), gotrans.KindUnknown},
+	}
+	for _, c := range cases {
+		require.Equal(t, c.want, classify(c.err), "%v", c.err)
+	}
+}
+
+func TestWrapError(t *testing.T) {
+	cause := &MySQLError{Number: 1062, Message: "Duplicate entry"}
+	err := wrapError("translationRepository.MassCreateOrUpdate", "product", gotrans.LocaleEN, []int{1, 2}, cause)
+
+	var ge *gotrans.Error
+	require.ErrorAs(t, err, &ge)
+	require.Equal(t, gotrans.KindConflict, ge.Kind)
+	require.Equal(t, []int{1, 2}, ge.IDs)
+	require.ErrorIs(t, err, cause)
+	require.Equal(t, "gotrans: translationRepository.MassCreateOrUpdate product locale=en ids=[1 2]: conflict: Error 1062: Duplicate entry", err.Error())
+	require.NoError(t, wrapError("op", "product", gotrans.LocaleEN, nil, nil))
+}
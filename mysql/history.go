@@ -0,0 +1,137 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ivan-gorbushko/gotrans"
+	"github.com/jmoiron/sqlx"
+)
+
+// Revision is a row of the translation_history table.
+type Revision struct {
+	ID        int64     `db:"id"`
+	Entity    string    `db:"entity"`
+	EntityID  int       `db:"entity_id"`
+	Field     string    `db:"field"`
+	Locale    string    `db:"locale"`
+	Kind      uint8     `db:"kind"`
+	OldValue  string    `db:"old_value"`
+	NewValue  string    `db:"new_value"`
+	Actor     string    `db:"actor"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type historyStore struct {
+	db *sqlx.DB
+}
+
+var _ gotrans.HistoryStore = (*historyStore)(nil)
+
+// NewHistoryStore returns a gotrans.HistoryStore backed by the
+// translation_history table.
+func NewHistoryStore(db *sqlx.DB) gotrans.HistoryStore {
+	return &historyStore{db: db}
+}
+
+func (s *historyStore) AppendRevisions(ctx context.Context, revs []gotrans.Revision) error {
+	const op = "historyStore.AppendRevisions"
+	if len(revs) == 0 {
+		return nil
+	}
+	entity := revs[0].Entity
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return wrapError(op, entity, gotrans.LocaleNone, nil, fmt.Errorf("begin tx: %w", err))
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	// One insert per row: the IDs are needed and LastInsertId only reports
+	// the first row of a multi-row insert.
+	query := tx.Rebind(`INSERT INTO translation_history
+		(entity, entity_id, field, locale, kind, old_value, new_value, actor, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	for i, r := range revs {
+		res, err := tx.ExecContext(ctx, query,
+			r.Entity, r.EntityID, r.Field, r.Locale.String(), uint8(r.Kind),
+			r.OldValue, r.NewValue, r.Actor, r.At.UTC())
+		if err != nil {
+			return wrapError(op, r.Entity, r.Locale, []int{r.EntityID}, err)
+		}
+		if revs[i].ID, err = res.LastInsertId(); err != nil {
+			return wrapError(op, r.Entity, r.Locale, []int{r.EntityID}, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return wrapError(op, entity, gotrans.LocaleNone, nil, fmt.Errorf("commit: %w", err))
+	}
+	return nil
+}
+
+func (s *historyStore) Revisions(ctx context.Context, key gotrans.TranslationKey) ([]gotrans.Revision, error) {
+	const op = "historyStore.Revisions"
+	var rows []Revision
+	err := s.db.SelectContext(ctx, &rows, s.db.Rebind(`SELECT
+		id, entity, entity_id, field, locale, kind, old_value, new_value, actor, created_at
+		FROM translation_history
+		WHERE entity = ? AND entity_id = ? AND field = ? AND locale = ?
+		ORDER BY id DESC`),
+		key.Entity, key.EntityID, key.Field, key.Locale.String())
+	if err != nil {
+		return nil, wrapError(op, key.Entity, key.Locale, []int{key.EntityID}, err)
+	}
+
+	revs := make([]gotrans.Revision, len(rows))
+	for i, r := range rows {
+		revs[i] = gotrans.Revision{
+			ID:             r.ID,
+			TranslationKey: key,
+			Kind:           gotrans.RevisionKind(r.Kind),
+			OldValue:       r.OldValue,
+			NewValue:       r.NewValue,
+			Actor:          r.Actor,
+			At:             r.CreatedAt,
+		}
+	}
+	return revs, nil
+}
+
+// PruneRevisions deletes by age with a plain range delete and by count with
+// a ROW_NUMBER window, which needs MySQL 8.0 or SQLite 3.25.
+func (s *historyStore) PruneRevisions(ctx context.Context, policy gotrans.RetentionPolicy) (int, error) {
+	const op = "historyStore.PruneRevisions"
+	var conds []string
+	var args []any
+	if policy.MaxAge > 0 {
+		conds = append(conds, "created_at < ?")
+		args = append(args, time.Now().Add(-policy.MaxAge).UTC())
+	}
+	if policy.MaxRevisions > 0 {
+		// The extra derived table lets MySQL select from the table it
+		// deletes from.
+		conds = append(conds, `id IN (SELECT id FROM (
+			SELECT id, ROW_NUMBER() OVER (
+				PARTITION BY entity, entity_id, field, locale ORDER BY id DESC
+			) AS n FROM translation_history
+		) ranked WHERE n > ?)`)
+		args = append(args, policy.MaxRevisions)
+	}
+	if len(conds) == 0 {
+		return 0, nil
+	}
+
+	res, err := s.db.ExecContext(ctx,
+		s.db.Rebind("DELETE FROM translation_history WHERE "+strings.Join(conds, " OR ")), args...)
+	if err != nil {
+		return 0, wrapError(op, "", gotrans.LocaleNone, nil, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, wrapError(op, "", gotrans.LocaleNone, nil, err)
+	}
+	return int(n), nil
+}
@@ -0,0 +1,61 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ivan-gorbushko/gotrans"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryStore(t *testing.T) {
+	db := newTestDB(t)
+	_, err := db.Exec(`
+		CREATE TABLE translation_history (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			entity TEXT,
+			entity_id INTEGER,
+			field TEXT,
+			locale TEXT,
+			kind INTEGER,
+			old_value TEXT,
+			new_value TEXT,
+			actor TEXT,
+			created_at DATETIME
+		)`)
+	require.NoError(t, err)
+	store := NewHistoryStore(db)
+	ctx := context.Background()
+
+	key := gotrans.TranslationKey{Entity: "product", EntityID: 1, Field: "title", Locale: gotrans.LocaleENGB}
+	other := key
+	other.EntityID = 2
+	old := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Now().UTC().Truncate(time.Second)
+	revs := []gotrans.Revision{
+		{TranslationKey: key, Kind: gotrans.RevisionCreate, NewValue: "Mobile", Actor: "alice", At: old},
+		{TranslationKey: key, Kind: gotrans.RevisionUpdate, OldValue: "Mobile", NewValue: "Phone", Actor: "bob", At: now},
+		{TranslationKey: key, Kind: gotrans.RevisionUpdate, OldValue: "Phone", NewValue: "Handset", At: now},
+		{TranslationKey: other, Kind: gotrans.RevisionCreate, NewValue: "Tablet", At: now},
+	}
+	require.NoError(t, store.AppendRevisions(ctx, revs))
+	require.NotZero(t, revs[3].ID)
+
+	got, err := store.Revisions(ctx, key)
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	require.Equal(t, revs[2], got[0])
+	require.Equal(t, revs[0].At, got[2].At.UTC())
+
+	n, err := store.PruneRevisions(ctx, gotrans.RetentionPolicy{MaxAge: time.Hour, MaxRevisions: 1})
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	got, err = store.Revisions(ctx, key)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, "Handset", got[0].NewValue)
+	got, err = store.Revisions(ctx, other)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+}
@@ -0,0 +1,231 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ivan-gorbushko/gotrans"
+)
+
+// UnknownLocaleStrategy decides what the repository does with a stored row
+// whose locale code doesn't map to a gotrans.Locale, e.g. one written by
+// another tool as "brazilian" or "zh_TW". A read for one locale only selects
+// its code and aliases and never meets such rows; the strategy applies to the
+// reads that scan every locale: GetTranslations with gotrans.LocaleNone,
+// Changes, and TranslationsByStatus and StaleTranslations with
+// gotrans.LocaleNone.
+type UnknownLocaleStrategy uint8
+
+const (
+	// UnknownLocaleAsNone loads the row with gotrans.LocaleNone. It is the
+	// historical behaviour and the default of NewTranslationRepository.
+	UnknownLocaleAsNone UnknownLocaleStrategy = iota
+	// UnknownLocaleError fails the read with a gotrans.KindValidation error
+	// wrapping gotrans.ErrUnknownLocale.
+	UnknownLocaleError
+	// UnknownLocaleSkip drops the row and counts it in Options.Diagnostics.
+	UnknownLocaleSkip
+)
+
+// Options configures a repository created by NewTranslationRepositoryWithOptions.
+type Options struct {
+	// UnknownLocales applies to codes that neither gotrans.ParseLocale nor
+	// Aliases resolve.
+	UnknownLocales UnknownLocaleStrategy
+	// Aliases maps stored codes to locales and is consulted before the
+	// strategy. Rows stored under an alias are loaded with its locale, so
+	// spell keys as they are stored; when resolving a row, keys also match
+	// case-insensitively with '_' and '-' equivalent.
+	Aliases map[string]gotrans.Locale
+	// Diagnostics, if set, counts rows skipped by UnknownLocaleSkip.
+	Diagnostics *Diagnostics
+	// Workflow stores and loads gotrans.Translation.Status in the status
+	// column, which must exist. Without it every row is published.
+	Workflow bool
+	// SourceHashes stores gotrans.Translation.SourceHash in the source_hash
+	// column, which must exist, for stale-translation tracking.
+	SourceHashes bool
+	// Versions maintains gotrans.Translation.Version in the version column,
+	// which must exist, and checks it for conditional saves (see
+	// gotrans.WithVersionCheck).
+	Versions bool
+	// Timestamps maintains gotrans.Translation.CreatedAt and UpdatedAt in
+	// the created_at and updated_at columns, records deletions in the
+	// translation_tombstones table and orders both by the change_seq column
+	// and the translation_change_seq counter, for Changes. All must exist.
+	Timestamps bool
+}
+
+// Diagnostics counts rows the repository dropped because of their locale.
+// The zero value is ready to use and safe for concurrent use.
+type Diagnostics struct {
+	mu      sync.Mutex
+	skipped map[string]int
+}
+
+// SkippedLocales returns how many rows were skipped per stored locale code.
+func (d *Diagnostics) SkippedLocales() map[string]int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	m := make(map[string]int, len(d.skipped))
+	for code, n := range d.skipped {
+		m[code] = n
+	}
+	return m
+}
+
+// Skipped returns the total number of skipped rows.
+func (d *Diagnostics) Skipped() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	n := 0
+	for _, c := range d.skipped {
+		n += c
+	}
+	return n
+}
+
+func (d *Diagnostics) skip(code string) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.skipped == nil {
+		d.skipped = make(map[string]int)
+	}
+	d.skipped[code]++
+}
+
+// UnknownLocale is a locale code present in storage that queries by locale
+// miss, with the number of rows using it. Locale is what the code parses to
+// when it is merely not canonical, e.g. gotrans.LocalePTBR for "pt_BR", and
+// gotrans.LocaleNone when it doesn't resolve at all.
+type UnknownLocale struct {
+	Code   string
+	Rows   int
+	Locale gotrans.Locale
+}
+
+// Repository is the repository returned by NewTranslationRepositoryWithOptions.
+type Repository interface {
+	gotrans.TranslationRepository
+	// UnknownLocales lists the distinct stored locale codes that resolve
+	// neither through gotrans.ParseLocale nor the configured aliases, or that
+	// parse but aren't stored in canonical form and aren't aliases, sorted by
+	// code.
+	UnknownLocales(ctx context.Context) ([]UnknownLocale, error)
+	// TranslationsByStatus lists the translations of entity in locale, or in
+	// every locale for gotrans.LocaleNone, that are in status, ordered by
+	// entity ID and field.
+	TranslationsByStatus(ctx context.Context, entity string, locale gotrans.Locale, status gotrans.TranslationStatus) ([]gotrans.Translation, error)
+	// StaleTranslations lists the translations of entity in locale, or in
+	// every locale but source for gotrans.LocaleNone, whose source-locale
+	// value changed after they were saved, ordered by entity ID, field and
+	// locale.
+	StaleTranslations(ctx context.Context, entity string, locale, source gotrans.Locale) ([]gotrans.Translation, error)
+	gotrans.ChangeFeed
+	// PruneTombstones deletes the tombstones of deletions made before
+	// before and returns how many it deleted.
+	PruneTombstones(ctx context.Context, before time.Time) (int, error)
+}
+
+var _ Repository = (*translationRepository)(nil)
+
+func (t *translationRepository) UnknownLocales(ctx context.Context) ([]UnknownLocale, error) {
+	const op = "translationRepository.UnknownLocales"
+	var rows []struct {
+		Locale string `db:"locale"`
+		Rows   int    `db:"n"`
+	}
+	err := t.db.SelectContext(ctx, &rows, `SELECT locale, COUNT(*) AS n FROM translations GROUP BY locale`)
+	if err != nil {
+		return nil, wrapError(op, "", gotrans.LocaleNone, nil, err)
+	}
+	var unknown []UnknownLocale
+	for _, r := range rows {
+		l, ok := t.resolveLocale(r.Locale)
+		if !ok {
+			l = gotrans.LocaleNone
+		}
+		if !ok || r.Locale != l.String() && !slices.Contains(t.aliasCodes, r.Locale) {
+			unknown = append(unknown, UnknownLocale{Code: r.Locale, Rows: r.Rows, Locale: l})
+		}
+	}
+	sort.Slice(unknown, func(i, j int) bool { return unknown[i].Code < unknown[j].Code })
+	return unknown, nil
+}
+
+// resolveLocale maps a stored code through gotrans.ParseLocale, then the aliases.
+func (t *translationRepository) resolveLocale(code string) (gotrans.Locale, bool) {
+	if l, ok := gotrans.ParseLocale(code); ok {
+		return l, true
+	}
+	l, ok := t.aliases[aliasKey(code)]
+	return l, ok
+}
+
+// localeCodes returns the stored codes to query for l: its own code and every
+// alias that maps to it.
+func (t *translationRepository) localeCodes(l gotrans.Locale) []string {
+	codes := []string{l.String()}
+	for _, code := range t.aliasCodes {
+		if al, _ := t.resolveLocale(code); al == l && code != codes[0] {
+			codes = append(codes, code)
+		}
+	}
+	return codes
+}
+
+func aliasKey(code string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(code)), "_", "-")
+}
+
+// toTranslateModel converts a stored row. ok is false when the row is skipped.
+func (t *translationRepository) toTranslateModel(mt Translation) (tr gotrans.Translation, ok bool, err error) {
+	locale, known := t.resolveLocale(mt.Locale)
+	if !known {
+		switch t.unknownLocales {
+		case UnknownLocaleError:
+			return tr, false, fmt.Errorf("%w %q in row %d", gotrans.ErrUnknownLocale, mt.Locale, mt.ID)
+		case UnknownLocaleSkip:
+			t.diagnostics.skip(mt.Locale)
+			return tr, false, nil
+		}
+		locale = gotrans.LocaleNone
+	}
+	return gotrans.Translation{
+		ID:         mt.ID,
+		Entity:     mt.Entity,
+		EntityID:   mt.EntityID,
+		Field:      mt.Field,
+		Locale:     locale,
+		Value:      mt.Value,
+		Status:     gotrans.TranslationStatus(mt.Status),
+		SourceHash: mt.SourceHash,
+		Version:    mt.Version,
+		CreatedAt:  mt.CreatedAt,
+		UpdatedAt:  mt.UpdatedAt,
+	}, true, nil
+}
+
+// toTranslateModels converts the rows of a listing query, dropping rows
+// skipped by the unknown-locale strategy.
+func (t *translationRepository) toTranslateModels(op, entity string, locale gotrans.Locale, rows []Translation) ([]gotrans.Translation, error) {
+	result := make([]gotrans.Translation, 0, len(rows))
+	for _, mt := range rows {
+		tr, ok, err := t.toTranslateModel(mt)
+		if err != nil {
+			return nil, wrapError(op, entity, locale, []int{mt.EntityID}, err)
+		}
+		if ok {
+			result = append(result, tr)
+		}
+	}
+	return result, nil
+}
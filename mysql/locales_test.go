@@ -0,0 +1,160 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ivan-gorbushko/gotrans"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDB(t *testing.T) *sqlx.DB {
+	db, err := sqlx.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	_, err = db.Exec(`
+		CREATE TABLE translations (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			entity TEXT,
+			entity_id INTEGER,
+			field TEXT,
+			locale TEXT,
+			value TEXT,
+			UNIQUE(entity, entity_id, field, locale)
+		)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO translations (entity, entity_id, field, locale, value) VALUES
+		('product', 1, 'title', 'en', 'Phone'),
+		('product', 1, 'title', 'pt_BR', 'Telefone'),
+		('product', 2, 'title', 'pt_BR', 'Tablet'),
+		('product', 1, 'title', 'tlh', 'ghogh')`)
+	require.NoError(t, err)
+	return db
+}
+
+func TestRepository_UnknownLocaleStrategy(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+	list := func(repo Repository) ([]gotrans.Translation, error) {
+		return repo.TranslationsByStatus(ctx, "product", gotrans.LocaleNone, gotrans.StatusPublished)
+	}
+
+	trs, err := list(NewTranslationRepositoryWithOptions(db, Options{}))
+	require.NoError(t, err)
+	require.Len(t, trs, 4)
+	require.Equal(t, gotrans.LocaleNone, trs[2].Locale, "default keeps the historical behaviour")
+
+	strict := NewTranslationRepositoryWithOptions(db, Options{UnknownLocales: UnknownLocaleError})
+	_, err = list(strict)
+	require.ErrorIs(t, err, gotrans.ErrUnknownLocale)
+	require.Equal(t, gotrans.KindValidation, gotrans.KindOf(err))
+	trs, err = strict.GetTranslations(ctx, gotrans.LocaleEN, "product", []int{1})
+	require.NoError(t, err, "reads of one locale never meet unknown codes")
+	require.Len(t, trs, 1)
+
+	diag := &Diagnostics{}
+	skip := NewTranslationRepositoryWithOptions(db, Options{UnknownLocales: UnknownLocaleSkip, Diagnostics: diag})
+	trs, err = list(skip)
+	require.NoError(t, err)
+	require.Len(t, trs, 3)
+	_, err = list(skip)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"tlh": 2}, diag.SkippedLocales())
+	require.Equal(t, 2, diag.Skipped())
+
+	trs, err = list(NewTranslationRepositoryWithOptions(db, Options{UnknownLocales: UnknownLocaleError, Aliases: map[string]gotrans.Locale{"TLH": gotrans.LocaleEN}}))
+	require.NoError(t, err)
+	require.Len(t, trs, 4)
+	require.Equal(t, gotrans.LocaleEN, trs[2].Locale, "aliases match case-insensitively")
+}
+
+func TestRepository_UnknownLocaleStrategyAllLocales(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+
+	trs, err := NewTranslationRepositoryWithOptions(db, Options{}).GetTranslations(ctx, gotrans.LocaleNone, "product", []int{1})
+	require.NoError(t, err)
+	require.Len(t, trs, 3, "LocaleNone reads every stored locale")
+	require.ElementsMatch(t, []gotrans.Locale{gotrans.LocaleEN, gotrans.LocalePTBR, gotrans.LocaleNone},
+		[]gotrans.Locale{trs[0].Locale, trs[1].Locale, trs[2].Locale})
+
+	_, err = NewTranslationRepositoryWithOptions(db, Options{UnknownLocales: UnknownLocaleError}).
+		GetTranslations(ctx, gotrans.LocaleNone, "product", []int{1})
+	require.ErrorIs(t, err, gotrans.ErrUnknownLocale)
+
+	diag := &Diagnostics{}
+	skip := NewTranslationRepositoryWithOptions(db, Options{UnknownLocales: UnknownLocaleSkip, Diagnostics: diag})
+	trs, err = skip.GetTranslations(ctx, gotrans.LocaleNone, "product", []int{1, 2})
+	require.NoError(t, err)
+	require.Len(t, trs, 3)
+	require.Equal(t, map[string]int{"tlh": 1}, diag.SkippedLocales())
+
+	cached := gotrans.NewCachedRepositoryInMemory(skip, gotrans.CacheOptions{})
+	_, err = cached.GetTranslations(ctx, gotrans.LocaleNone, "product", []int{2})
+	require.NoError(t, err)
+	require.NoError(t, cached.MassCreateOrUpdate(ctx, gotrans.LocaleFR, []gotrans.Translation{
+		{Entity: "product", EntityID: 2, Field: "title", Locale: gotrans.LocaleFR, Value: "Tablette"},
+	}))
+	trs, err = cached.GetTranslations(ctx, gotrans.LocaleNone, "product", []int{2})
+	require.NoError(t, err)
+	require.Len(t, trs, 2, "reads of every locale are not cached")
+}
+
+func TestRepository_Aliases(t *testing.T) {
+	repo := NewTranslationRepositoryWithOptions(newTestDB(t), Options{
+		Aliases: map[string]gotrans.Locale{"pt_BR": gotrans.LocalePTBR},
+	})
+
+	trs, err := repo.GetTranslations(context.Background(), gotrans.LocalePTBR, "product", []int{1, 2})
+	require.NoError(t, err)
+	require.Len(t, trs, 2)
+	for _, tr := range trs {
+		require.Equal(t, gotrans.LocalePTBR, tr.Locale)
+	}
+
+	trs, err = NewTranslationRepository(repo.(*translationRepository).db).
+		GetTranslations(context.Background(), gotrans.LocalePTBR, "product", []int{1, 2})
+	require.NoError(t, err)
+	require.Empty(t, trs, "without the alias the non-canonical rows are not queried")
+}
+
+func TestRepository_UnknownLocales(t *testing.T) {
+	db := newTestDB(t)
+
+	unknown, err := NewTranslationRepositoryWithOptions(db, Options{}).UnknownLocales(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []UnknownLocale{
+		{Code: "pt_BR", Rows: 2, Locale: gotrans.LocalePTBR},
+		{Code: "tlh", Rows: 1},
+	}, unknown)
+
+	unknown, err = NewTranslationRepositoryWithOptions(db, Options{
+		Aliases: map[string]gotrans.Locale{"pt_BR": gotrans.LocalePTBR, "tlh": gotrans.LocaleEN},
+	}).UnknownLocales(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, unknown)
+}
+
+func TestRepository_RegisteredLocale(t *testing.T) {
+	hi := gotrans.MustRegisterLocale(gotrans.LocaleDefinition{Code: "hi", Name: "Hindi", NativeName: "हिन्दी"})
+	repo := NewTranslationRepositoryWithOptions(newTestDB(t), Options{UnknownLocales: UnknownLocaleError})
+	ctx := context.Background()
+
+	err := repo.MassCreateOrUpdate(ctx, hi, []gotrans.Translation{
+		{Entity: "product", EntityID: 3, Field: "title", Locale: hi, Value: "फ़ोन"},
+	})
+	require.NoError(t, err)
+	trs, err := repo.GetTranslations(ctx, hi, "product", []int{3})
+	require.NoError(t, err)
+	require.Len(t, trs, 1)
+	require.Equal(t, hi, trs[0].Locale)
+
+	unknown, err := repo.UnknownLocales(ctx)
+	require.NoError(t, err)
+	for _, u := range unknown {
+		require.NotEqual(t, "hi", u.Code)
+	}
+}
@@ -4,14 +4,24 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/ivan-gorbushko/gotrans"
 	"github.com/jmoiron/sqlx"
 )
 
 type translationRepository struct {
-	db *sqlx.DB
+	db             *sqlx.DB
+	unknownLocales UnknownLocaleStrategy
+	aliases        map[string]gotrans.Locale // by aliasKey
+	aliasCodes     []string                  // alias keys as configured, sorted
+	diagnostics    *Diagnostics
+	workflow       bool
+	sourceHashes   bool
+	versions       bool
+	timestamps     bool
 }
 
 var _ gotrans.TranslationRepository = (*translationRepository)(nil)
@@ -20,6 +30,29 @@ func NewTranslationRepository(db *sqlx.DB) gotrans.TranslationRepository {
 	return &translationRepository{db: db}
 }
 
+// NewTranslationRepositoryWithOptions is NewTranslationRepository with
+// control over rows whose stored locale code is unknown.
+func NewTranslationRepositoryWithOptions(db *sqlx.DB, opts Options) Repository {
+	aliases := make(map[string]gotrans.Locale, len(opts.Aliases))
+	codes := make([]string, 0, len(opts.Aliases))
+	for code, l := range opts.Aliases {
+		aliases[aliasKey(code)] = l
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return &translationRepository{
+		db:             db,
+		unknownLocales: opts.UnknownLocales,
+		aliases:        aliases,
+		aliasCodes:     codes,
+		diagnostics:    opts.Diagnostics,
+		workflow:       opts.Workflow,
+		sourceHashes:   opts.SourceHashes,
+		versions:       opts.Versions,
+		timestamps:     opts.Timestamps,
+	}
+}
+
 func (t *translationRepository) GetTranslations(
 	ctx context.Context,
 	locale gotrans.Locale,
@@ -35,23 +68,37 @@ func (t *translationRepository) GetTranslations(
 		if end > len(entityIDs) {
 			end = len(entityIDs)
 		}
+		// LocaleNone reads every stored locale, like MassDelete deletes them;
+		// codes that don't resolve then go through the unknown-locale strategy.
 		query, args, err := sqlx.In(
-			`SELECT id, entity, entity_id, field, locale, value FROM translations WHERE entity = ? AND locale = ? AND entity_id IN (?)`,
-			entity, locale.String(), entityIDs[start:end],
+			`SELECT `+t.columns("")+` FROM translations WHERE entity = ? AND locale IN (?) AND entity_id IN (?)`,
+			entity, t.localeCodes(locale), entityIDs[start:end],
 		)
+		if locale == gotrans.LocaleNone {
+			query, args, err = sqlx.In(
+				`SELECT `+t.columns("")+` FROM translations WHERE entity = ? AND entity_id IN (?)`,
+				entity, entityIDs[start:end],
+			)
+		}
 		if err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
+			return nil, wrapError(op, entity, locale, entityIDs[start:end], err)
 		}
 		var batch []Translation
 		if err = t.db.SelectContext(ctx, &batch, t.db.Rebind(query), args...); err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
+			return nil, wrapError(op, entity, locale, entityIDs[start:end], err)
 		}
 		all = append(all, batch...)
 	}
 
-	result := make([]gotrans.Translation, len(all))
-	for i, mt := range all {
-		result[i] = toTranslateModel(mt)
+	result := make([]gotrans.Translation, 0, len(all))
+	for _, mt := range all {
+		tr, ok, err := t.toTranslateModel(mt)
+		if err != nil {
+			return nil, wrapError(op, entity, locale, []int{mt.EntityID}, err)
+		}
+		if ok {
+			result = append(result, tr)
+		}
 	}
 	return result, nil
 }
@@ -64,15 +111,99 @@ func (t *translationRepository) MassDelete(
 	fields []string,
 ) error {
 	const op = "translationRepository.MassDelete"
-	if err := t.massDelete(ctx, t.db, locale, entity, entityIDs, fields); err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+	expected, check := gotrans.DeleteVersionsFromContext(ctx)
+	check = check && t.versions
+	if !t.timestamps && !check {
+		return wrapError(op, entity, locale, entityIDs, t.massDelete(ctx, t.db, locale, entity, entityIDs, fields))
+	}
+
+	// Read the rows before deleting them to check their versions and leave
+	// their tombstones.
+	tx, err := t.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return wrapError(op, entity, locale, entityIDs, fmt.Errorf("begin tx: %w", err))
+	}
+	defer tx.Rollback() //nolint:errcheck
+	var seq int64
+	if t.timestamps {
+		if seq, err = nextChangeSeq(ctx, tx); err != nil {
+			return wrapError(op, entity, locale, entityIDs, err)
+		}
+	}
+
+	columns := "entity, entity_id, field, locale"
+	if check {
+		columns += ", version"
+	}
+	where, args := deleteFilter(locale, entity, entityIDs, fields)
+	query := `SELECT ` + columns + ` FROM translations WHERE ` + where
+	if check && t.db.DriverName() != "sqlite3" {
+		query += ` FOR UPDATE`
+	}
+	query, args, err = sqlx.In(query, args...)
+	if err != nil {
+		return wrapError(op, entity, locale, entityIDs, err)
+	}
+	var deleted []deletedRow
+	if err = tx.SelectContext(ctx, &deleted, tx.Rebind(query), args...); err != nil {
+		return wrapError(op, entity, locale, entityIDs, err)
+	}
+	if check {
+		if conflicts := t.deleteConflicts(locale, deleted, expected); len(conflicts) > 0 {
+			return wrapError(op, entity, locale, entityIDs, &gotrans.VersionConflictError{Conflicts: conflicts})
+		}
+	}
+	if err = t.massDelete(ctx, tx, locale, entity, entityIDs, fields); err != nil {
+		return wrapError(op, entity, locale, entityIDs, err)
+	}
+	if t.timestamps {
+		tombstones := make([]Tombstone, len(deleted))
+		for i, r := range deleted {
+			tombstones[i] = r.Tombstone
+		}
+		if err = insertTombstones(ctx, tx, tombstones, now(), seq); err != nil {
+			return wrapError(op, entity, locale, entityIDs, err)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return wrapError(op, entity, locale, entityIDs, fmt.Errorf("commit: %w", err))
 	}
 	return nil
 }
 
-// MassCreateOrUpdate deletes existing translations for the affected
-// (entity, entityID, field) combinations and inserts the new ones,
-// all within a single transaction to guarantee atomicity.
+// deletedRow is a row MassDelete is about to remove.
+type deletedRow struct {
+	Tombstone
+	Version int64 `db:"version"`
+}
+
+// deleteConflicts compares the rows a conditional MassDelete would remove,
+// and the keys it expects to, with the expected versions.
+func (t *translationRepository) deleteConflicts(locale gotrans.Locale, rows []deletedRow, expected map[gotrans.TranslationKey]int64) []gotrans.VersionConflict {
+	var conflicts []gotrans.VersionConflict
+	found := make(map[gotrans.TranslationKey]struct{}, len(rows))
+	for _, r := range rows {
+		key := gotrans.TranslationKey{Entity: r.Entity, EntityID: r.EntityID, Field: r.Field, Locale: locale}
+		if locale == gotrans.LocaleNone {
+			key.Locale, _ = t.resolveLocale(r.Locale)
+		}
+		found[key] = struct{}{}
+		if want := expected[key]; want != r.Version {
+			conflicts = append(conflicts, gotrans.VersionConflict{TranslationKey: key, Expected: want, Actual: r.Version})
+		}
+	}
+	for key, want := range expected {
+		if _, ok := found[key]; !ok && want != 0 {
+			conflicts = append(conflicts, gotrans.VersionConflict{TranslationKey: key, Expected: want})
+		}
+	}
+	return conflicts
+}
+
+// MassCreateOrUpdate deletes the stored translations of exactly the
+// (entity, entityID, field) combinations in the batch and inserts the new
+// ones, all within a single transaction to guarantee atomicity. Other
+// fields of the same entities are left alone.
 func (t *translationRepository) MassCreateOrUpdate(
 	ctx context.Context,
 	locale gotrans.Locale,
@@ -83,58 +214,148 @@ func (t *translationRepository) MassCreateOrUpdate(
 		return nil
 	}
 
-	// Collect the set of (entity → IDs, fields) to delete before inserting.
-	type deleteParams struct {
-		IDs    map[int]struct{}
-		Fields map[string]struct{}
-	}
-	entityMap := make(map[string]*deleteParams)
+	// Collect the IDs written per (entity, field), in order of appearance,
+	// to replace exactly those rows.
+	type scopeKey struct{ entity, field string }
+	scopes := make(map[scopeKey][]int)
+	var order []scopeKey
 	for _, tr := range translations {
-		if _, ok := entityMap[tr.Entity]; !ok {
-			entityMap[tr.Entity] = &deleteParams{
-				IDs:    make(map[int]struct{}),
-				Fields: make(map[string]struct{}),
-			}
+		k := scopeKey{tr.Entity, tr.Field}
+		if _, ok := scopes[k]; !ok {
+			order = append(order, k)
 		}
-		entityMap[tr.Entity].IDs[tr.EntityID] = struct{}{}
-		entityMap[tr.Entity].Fields[tr.Field] = struct{}{}
+		scopes[k] = append(scopes[k], tr.EntityID)
 	}
 
+	entity, ids := translations[0].Entity, translationIDs(translations)
 	tx, err := t.db.BeginTxx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("%s: begin tx: %w", op, err)
+		return wrapError(op, entity, locale, ids, fmt.Errorf("begin tx: %w", err))
 	}
 	defer tx.Rollback() //nolint:errcheck
-
-	for entity, params := range entityMap {
-		ids := make([]int, 0, len(params.IDs))
-		for id := range params.IDs {
-			ids = append(ids, id)
+	var seq int64
+	if t.timestamps {
+		if seq, err = nextChangeSeq(ctx, tx); err != nil {
+			return wrapError(op, entity, locale, ids, err)
 		}
-		fields := make([]string, 0, len(params.Fields))
-		for f := range params.Fields {
-			fields = append(fields, f)
+	}
+
+	stored := make(map[gotrans.TranslationKey]storedRow)
+	for _, k := range order {
+		ids := scopes[k]
+		if t.versions || t.timestamps {
+			if err = t.storedRows(ctx, tx, locale, k.entity, ids, []string{k.field}, stored); err != nil {
+				return wrapError(op, k.entity, locale, ids, err)
+			}
 		}
-		if err = t.massDelete(ctx, tx, locale, entity, ids, fields); err != nil {
-			return fmt.Errorf("%s: %w", op, err)
+		if err = t.massDelete(ctx, tx, locale, k.entity, ids, []string{k.field}); err != nil {
+			return wrapError(op, k.entity, locale, ids, err)
 		}
 	}
 
 	rows := make([]Translation, len(translations))
+	var conflicts []gotrans.VersionConflict
+	check := t.versions && gotrans.VersionCheckFromContext(ctx)
+	at := now()
 	for i, tr := range translations {
 		rows[i] = toMysqlTranslateModel(tr)
+		key := gotrans.TranslationKey{Entity: tr.Entity, EntityID: tr.EntityID, Field: tr.Field, Locale: locale}
+		old, existed := stored[key]
+		if check && tr.Version != old.Version {
+			conflicts = append(conflicts, gotrans.VersionConflict{TranslationKey: key, Expected: tr.Version, Actual: old.Version})
+		}
+		rows[i].Version = old.Version + 1
+		rows[i].CreatedAt, rows[i].UpdatedAt = old.CreatedAt, at
+		rows[i].ChangeSeq = seq
+		if !existed || old.CreatedAt.IsZero() {
+			rows[i].CreatedAt = at
+		}
 	}
-	if err = massInsert(ctx, tx, rows); err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+	if len(conflicts) > 0 {
+		return wrapError(op, entity, locale, ids, &gotrans.VersionConflictError{Conflicts: conflicts})
+	}
+	if err = t.massInsert(ctx, tx, rows); err != nil {
+		return wrapError(op, entity, locale, ids, err)
 	}
 
-	return tx.Commit()
+	if err = tx.Commit(); err != nil {
+		return wrapError(op, entity, locale, ids, fmt.Errorf("commit: %w", err))
+	}
+	return nil
 }
 
 // ------------------------------------------------
 // --------------- Private helpers ----------------
 // ------------------------------------------------
 
+// columns returns the translations columns to select, each prefixed with
+// alias and a dot when alias isn't empty.
+func (t *translationRepository) columns(alias string) string {
+	columns := []string{"id", "entity", "entity_id", "field", "locale", "value"}
+	if t.workflow {
+		columns = append(columns, "status")
+	}
+	if t.sourceHashes {
+		columns = append(columns, "source_hash")
+	}
+	if t.versions {
+		columns = append(columns, "version")
+	}
+	if t.timestamps {
+		columns = append(columns, "created_at", "updated_at", "change_seq")
+	}
+	if alias != "" {
+		for i, c := range columns {
+			columns[i] = alias + "." + c
+		}
+	}
+	return strings.Join(columns, ", ")
+}
+
+// storedRow is what MassCreateOrUpdate carries over from the row it replaces.
+type storedRow struct {
+	EntityID  int       `db:"entity_id"`
+	Field     string    `db:"field"`
+	Version   int64     `db:"version"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// storedRows adds the stored rows of entity in locale for ids × fields to
+// into, locking them until the transaction ends.
+func (t *translationRepository) storedRows(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	locale gotrans.Locale,
+	entity string,
+	ids []int,
+	fields []string,
+	into map[gotrans.TranslationKey]storedRow,
+) error {
+	columns := "entity_id, field"
+	if t.versions {
+		columns += ", version"
+	}
+	if t.timestamps {
+		columns += ", created_at"
+	}
+	query := `SELECT ` + columns + ` FROM translations WHERE entity = ? AND locale = ? AND entity_id IN (?) AND field IN (?)`
+	if t.db.DriverName() != "sqlite3" { // SQLite locks the whole database instead
+		query += ` FOR UPDATE`
+	}
+	query, args, err := sqlx.In(query, entity, locale.String(), ids, fields)
+	if err != nil {
+		return err
+	}
+	var rows []storedRow
+	if err = tx.SelectContext(ctx, &rows, tx.Rebind(query), args...); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		into[gotrans.TranslationKey{Entity: entity, EntityID: r.EntityID, Field: r.Field, Locale: locale}] = r
+	}
+	return nil
+}
+
 // dbExec is satisfied by both *sqlx.DB and *sqlx.Tx.
 type dbExec interface {
 	Rebind(string) string
@@ -151,34 +372,54 @@ func (t *translationRepository) massDelete(
 	entityIDs []int,
 	fields []string,
 ) error {
-	query := "DELETE FROM translations WHERE entity = ?"
+	where, args := deleteFilter(locale, entity, entityIDs, fields)
+	query, args, err := sqlx.In("DELETE FROM translations WHERE "+where, args...)
+	if err != nil {
+		return err
+	}
+	_, err = exec.ExecContext(ctx, exec.Rebind(query), args...)
+	return err
+}
+
+// deleteFilter returns the WHERE clause, for sqlx.In, that selects the rows
+// MassDelete removes.
+func deleteFilter(locale gotrans.Locale, entity string, entityIDs []int, fields []string) (string, []any) {
+	where := "entity = ?"
 	args := []any{entity}
 
 	if locale != gotrans.LocaleNone {
-		query += " AND locale = ?"
+		where += " AND locale = ?"
 		args = append(args, locale.String())
 	}
 	if len(entityIDs) > 0 {
-		query += " AND entity_id IN (?)"
+		where += " AND entity_id IN (?)"
 		args = append(args, entityIDs)
 	}
 	if len(fields) > 0 {
-		query += " AND field IN (?)"
+		where += " AND field IN (?)"
 		args = append(args, fields)
 	}
-
-	query, args, err := sqlx.In(query, args...)
-	if err != nil {
-		return err
-	}
-	_, err = exec.ExecContext(ctx, exec.Rebind(query), args...)
-	return err
+	return where, args
 }
 
 // massInsert performs a single bulk INSERT for all rows using the provided transaction.
 // Rows are split into batches of insertBatchSize to stay within driver parameter limits.
-func massInsert(ctx context.Context, tx *sqlx.Tx, rows []Translation) error {
-	const insertBatchSize = 500 // 500 rows × 5 cols = 2500 params, safe for MySQL and SQLite
+func (t *translationRepository) massInsert(ctx context.Context, tx *sqlx.Tx, rows []Translation) error {
+	const insertBatchSize = 400 // 400 rows × 11 cols = 4400 params, safe for MySQL and SQLite
+	columns := []string{"entity", "entity_id", "field", "locale", "value"}
+	if t.workflow {
+		columns = append(columns, "status")
+	}
+	if t.sourceHashes {
+		columns = append(columns, "source_hash")
+	}
+	if t.versions {
+		columns = append(columns, "version")
+	}
+	if t.timestamps {
+		columns = append(columns, "created_at", "updated_at", "change_seq")
+	}
+	placeholder := "(?" + strings.Repeat(", ?", len(columns)-1) + ")"
 	for start := 0; start < len(rows); start += insertBatchSize {
 		end := start + insertBatchSize
 		if end > len(rows) {
@@ -187,13 +428,25 @@ func massInsert(ctx context.Context, tx *sqlx.Tx, rows []Translation) error {
 		batch := rows[start:end]
 
 		placeholders := make([]string, len(batch))
-		args := make([]any, 0, len(batch)*5)
+		args := make([]any, 0, len(batch)*len(columns))
 		for i, r := range batch {
-			placeholders[i] = "(?, ?, ?, ?, ?)"
+			placeholders[i] = placeholder
 			args = append(args, r.Entity, r.EntityID, r.Field, r.Locale, r.Value)
+			if t.workflow {
+				args = append(args, r.Status)
+			}
+			if t.sourceHashes {
+				args = append(args, r.SourceHash)
+			}
+			if t.versions {
+				args = append(args, r.Version)
+			}
+			if t.timestamps {
+				args = append(args, r.CreatedAt, r.UpdatedAt, r.ChangeSeq)
+			}
 		}
 
-		query := "INSERT INTO translations (entity, entity_id, field, locale, value) VALUES " +
+		query := "INSERT INTO translations (" + strings.Join(columns, ", ") + ") VALUES " +
 			strings.Join(placeholders, ", ")
 		if _, err := tx.ExecContext(ctx, tx.Rebind(query), args...); err != nil {
 			return err
@@ -202,28 +455,31 @@ func massInsert(ctx context.Context, tx *sqlx.Tx, rows []Translation) error {
 	return nil
 }
 
+// translationIDs returns the distinct entity IDs of translations.
+func translationIDs(translations []gotrans.Translation) []int {
+	seen := make(map[int]struct{}, len(translations))
+	ids := make([]int, 0, len(translations))
+	for _, tr := range translations {
+		if _, ok := seen[tr.EntityID]; !ok {
+			seen[tr.EntityID] = struct{}{}
+			ids = append(ids, tr.EntityID)
+		}
+	}
+	return ids
+}
+
 func toMysqlTranslateModel(tr gotrans.Translation) Translation {
 	return Translation{
-		ID:       tr.ID,
-		Entity:   tr.Entity,
-		EntityID: tr.EntityID,
-		Field:    tr.Field,
-		Locale:   tr.Locale.String(),
-		Value:    tr.Value,
-	}
-}
-
-func toTranslateModel(mt Translation) gotrans.Translation {
-	locale, ok := gotrans.ParseLocale(mt.Locale)
-	if !ok {
-		locale = gotrans.LocaleNone
-	}
-	return gotrans.Translation{
-		ID:       mt.ID,
-		Entity:   mt.Entity,
-		EntityID: mt.EntityID,
-		Field:    mt.Field,
-		Locale:   locale,
-		Value:    mt.Value,
+		ID:         tr.ID,
+		Entity:     tr.Entity,
+		EntityID:   tr.EntityID,
+		Field:      tr.Field,
+		Locale:     tr.Locale.String(),
+		Value:      tr.Value,
+		Status:     uint8(tr.Status),
+		SourceHash: tr.SourceHash,
+		Version:    tr.Version,
+		CreatedAt:  tr.CreatedAt,
+		UpdatedAt:  tr.UpdatedAt,
 	}
 }
@@ -0,0 +1,77 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ivan-gorbushko/gotrans"
+	"github.com/stretchr/testify/require"
+)
+
+type page struct {
+	ID      int
+	Locale  gotrans.Locale
+	Title   string
+	Body    string
+	Summary *string
+}
+
+func (p page) TranslationEntityID() int                { return p.ID }
+func (p page) TranslationEntityName() string           { return "page" }
+func (p page) TranslationEntityLocale() gotrans.Locale { return p.Locale }
+func (p page) TranslatableFields() map[string]string {
+	return map[string]string{"Title": "title", "Body": "body", "Summary": "summary"}
+}
+
+// storedValues returns the stored value per "id/field" of entity in locale.
+func storedValues(t *testing.T, repo gotrans.TranslationRepository, entity string, locale gotrans.Locale, ids ...int) map[string]string {
+	t.Helper()
+	trs, err := repo.GetTranslations(context.Background(), locale, entity, ids)
+	require.NoError(t, err)
+	values := make(map[string]string, len(trs))
+	for _, tr := range trs {
+		values[fmt.Sprintf("%d/%s", tr.EntityID, tr.Field)] = tr.Value
+	}
+	return values
+}
+
+func TestRepository_MassCreateOrUpdateReplacesOnlyWrittenFields(t *testing.T) {
+	repo := NewTranslationRepository(newTestDB(t))
+	trans := gotrans.NewTranslatorWithOptions(gotrans.TranslatorOptions[page]{Repository: repo, EmptyValues: gotrans.EmptyValueSkip})
+	ctx := context.Background()
+
+	require.NoError(t, trans.SaveTranslations(ctx, []page{
+		{ID: 1, Locale: gotrans.LocaleDE, Title: "T1", Body: "D1"},
+		{ID: 2, Locale: gotrans.LocaleDE, Title: "T2", Body: "D2"},
+	}))
+	require.NoError(t, trans.SaveTranslations(ctx, []page{
+		{ID: 1, Locale: gotrans.LocaleDE, Title: "T1", Body: "D1 edited"},
+		{ID: 2, Locale: gotrans.LocaleDE, Title: "T2 edited"},
+	}))
+
+	require.Equal(t, map[string]string{
+		"1/title": "T1", "1/body": "D1 edited",
+		"2/title": "T2 edited", "2/body": "D2",
+	}, storedValues(t, repo, "page", gotrans.LocaleDE, 1, 2), "a skipped field keeps its value when another entity writes it")
+}
+
+func TestRepository_MassCreateOrUpdateKeepsNilFields(t *testing.T) {
+	repo := NewTranslationRepository(newTestDB(t))
+	trans := gotrans.NewTranslator[page](repo)
+	ctx := context.Background()
+	text := func(s string) *string { return &s }
+
+	require.NoError(t, trans.SaveTranslations(ctx, []page{
+		{ID: 1, Locale: gotrans.LocaleDE, Title: "T1", Summary: text("S1")},
+		{ID: 2, Locale: gotrans.LocaleDE, Title: "T2", Summary: text("S2")},
+	}))
+	require.NoError(t, trans.SaveTranslations(ctx, []page{
+		{ID: 1, Locale: gotrans.LocaleDE, Title: "T1"},
+		{ID: 2, Locale: gotrans.LocaleDE, Title: "T2", Summary: text("S2 edited")},
+	}))
+
+	values := storedValues(t, repo, "page", gotrans.LocaleDE, 1, 2)
+	require.Equal(t, "S1", values["1/summary"], "a nil pointer is no translation, not a delete")
+	require.Equal(t, "S2 edited", values["2/summary"])
+}
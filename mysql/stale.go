@@ -0,0 +1,51 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/ivan-gorbushko/gotrans"
+	"github.com/jmoiron/sqlx"
+)
+
+// StaleTranslations needs Options.SourceHashes; without it nothing is stale.
+// A PluralText category is compared with any category of the same field in
+// source, since they all carry the same hash. Source rows without a hash,
+// stored before tracking started, make nothing stale.
+func (t *translationRepository) StaleTranslations(
+	ctx context.Context,
+	entity string,
+	locale, source gotrans.Locale,
+) ([]gotrans.Translation, error) {
+	const op = "translationRepository.StaleTranslations"
+	if !t.sourceHashes {
+		return nil, nil
+	}
+
+	query := `SELECT ` + t.columns("t") + ` FROM translations t WHERE t.entity = ?`
+	args := []any{entity}
+	if locale != gotrans.LocaleNone {
+		query += ` AND t.locale IN (?)`
+		args = append(args, t.localeCodes(locale))
+	} else {
+		query += ` AND t.locale NOT IN (?)`
+		args = append(args, t.localeCodes(source))
+	}
+	query += ` AND EXISTS (
+		SELECT 1 FROM translations s
+		WHERE s.entity = t.entity AND s.entity_id = t.entity_id AND s.locale IN (?)
+			AND s.source_hash <> '' AND s.source_hash <> t.source_hash
+			AND (s.field = t.field OR (INSTR(t.field, '#') > 0
+				AND SUBSTR(s.field, 1, INSTR(t.field, '#')) = SUBSTR(t.field, 1, INSTR(t.field, '#'))))
+	) ORDER BY t.entity_id, t.field, t.locale`
+	args = append(args, t.localeCodes(source))
+
+	query, args, err := sqlx.In(query, args...)
+	if err != nil {
+		return nil, wrapError(op, entity, locale, nil, err)
+	}
+	var rows []Translation
+	if err = t.db.SelectContext(ctx, &rows, t.db.Rebind(query), args...); err != nil {
+		return nil, wrapError(op, entity, locale, nil, err)
+	}
+	return t.toTranslateModels(op, entity, locale, rows)
+}
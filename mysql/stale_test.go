@@ -0,0 +1,59 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ivan-gorbushko/gotrans"
+	"github.com/stretchr/testify/require"
+)
+
+type product struct {
+	ID     int
+	Locale gotrans.Locale
+	Title  string
+}
+
+func (p product) TranslationEntityID() int                { return p.ID }
+func (p product) TranslationEntityName() string           { return "product" }
+func (p product) TranslationEntityLocale() gotrans.Locale { return p.Locale }
+func (p product) TranslatableFields() map[string]string {
+	return map[string]string{"Title": "title"}
+}
+
+func TestRepository_StaleTranslations(t *testing.T) {
+	db := newTestDB(t)
+	_, err := db.Exec(`ALTER TABLE translations ADD COLUMN source_hash TEXT NOT NULL DEFAULT ''`)
+	require.NoError(t, err)
+	repo := NewTranslationRepositoryWithOptions(db, Options{SourceHashes: true})
+	trans := gotrans.NewTranslatorWithOptions(gotrans.TranslatorOptions[product]{Repository: repo, SourceLocale: gotrans.LocaleEN})
+	ctx := context.Background()
+
+	require.NoError(t, trans.SaveTranslations(ctx, []product{{ID: 3, Locale: gotrans.LocaleFR, Title: "Téléphone"}}))
+	stale, err := repo.StaleTranslations(ctx, "product", gotrans.LocaleFR, gotrans.LocaleEN)
+	require.NoError(t, err)
+	require.Empty(t, stale, "nothing is stale without a source")
+
+	require.NoError(t, trans.SaveTranslations(ctx, []product{
+		{ID: 3, Locale: gotrans.LocaleEN, Title: "Phone"},
+		{ID: 3, Locale: gotrans.LocaleDE, Title: "Telefon"},
+	}))
+	stale, err = repo.StaleTranslations(ctx, "product", gotrans.LocaleFR, gotrans.LocaleEN)
+	require.NoError(t, err)
+	require.Len(t, stale, 1, "saved before the source existed")
+	stale, err = repo.StaleTranslations(ctx, "product", gotrans.LocaleDE, gotrans.LocaleEN)
+	require.NoError(t, err)
+	require.Empty(t, stale)
+
+	require.NoError(t, trans.SaveTranslations(ctx, []product{{ID: 3, Locale: gotrans.LocaleEN, Title: "Mobile phone"}}))
+	stale, err = repo.StaleTranslations(ctx, "product", gotrans.LocaleDE, gotrans.LocaleEN)
+	require.NoError(t, err)
+	require.Len(t, stale, 1)
+	require.Equal(t, "Telefon", stale[0].Value)
+
+	_, err = db.Exec(`UPDATE translations SET source_hash = '' WHERE locale = 'en'`)
+	require.NoError(t, err)
+	stale, err = repo.StaleTranslations(ctx, "product", gotrans.LocaleNone, gotrans.LocaleEN)
+	require.NoError(t, err)
+	require.Empty(t, stale, "a source stored before tracking makes nothing stale")
+}
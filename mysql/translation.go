@@ -1,10 +1,18 @@
 package mysql
 
+import "time"
+
 type Translation struct {
-	ID       int    `db:"id"`
-	Entity   string `db:"entity"`
-	EntityID int    `db:"entity_id"`
-	Field    string `db:"field"`
-	Locale   string `db:"locale"`
-	Value    string `db:"value"`
+	ID         int       `db:"id"`
+	Entity     string    `db:"entity"`
+	EntityID   int       `db:"entity_id"`
+	Field      string    `db:"field"`
+	Locale     string    `db:"locale"`
+	Value      string    `db:"value"`
+	Status     uint8     `db:"status"`
+	SourceHash string    `db:"source_hash"`
+	Version    int64     `db:"version"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+	ChangeSeq  int64     `db:"change_seq"`
 }
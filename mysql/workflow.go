@@ -0,0 +1,44 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/ivan-gorbushko/gotrans"
+	"github.com/jmoiron/sqlx"
+)
+
+// TranslationsByStatus needs Options.Workflow to tell statuses apart;
+// without it every row is published.
+func (t *translationRepository) TranslationsByStatus(
+	ctx context.Context,
+	entity string,
+	locale gotrans.Locale,
+	status gotrans.TranslationStatus,
+) ([]gotrans.Translation, error) {
+	const op = "translationRepository.TranslationsByStatus"
+	if !t.workflow && status != gotrans.StatusPublished {
+		return nil, nil
+	}
+
+	query := `SELECT ` + t.columns("") + ` FROM translations WHERE entity = ?`
+	args := []any{entity}
+	if locale != gotrans.LocaleNone {
+		query += ` AND locale IN (?)`
+		args = append(args, t.localeCodes(locale))
+	}
+	if t.workflow {
+		query += ` AND status = ?`
+		args = append(args, uint8(status))
+	}
+	query += ` ORDER BY entity_id, field, locale`
+	query, args, err := sqlx.In(query, args...)
+	if err != nil {
+		return nil, wrapError(op, entity, locale, nil, err)
+	}
+
+	var rows []Translation
+	if err = t.db.SelectContext(ctx, &rows, t.db.Rebind(query), args...); err != nil {
+		return nil, wrapError(op, entity, locale, nil, err)
+	}
+	return t.toTranslateModels(op, entity, locale, rows)
+}
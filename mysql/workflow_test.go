@@ -0,0 +1,44 @@
+package mysql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ivan-gorbushko/gotrans"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Workflow(t *testing.T) {
+	db := newTestDB(t)
+	_, err := db.Exec(`ALTER TABLE translations ADD COLUMN status INTEGER NOT NULL DEFAULT 0`)
+	require.NoError(t, err)
+	repo := NewTranslationRepositoryWithOptions(db, Options{Workflow: true})
+	ctx := context.Background()
+
+	require.NoError(t, repo.MassCreateOrUpdate(ctx, gotrans.LocaleDE, []gotrans.Translation{
+		{Entity: "product", EntityID: 1, Field: "title", Locale: gotrans.LocaleDE, Value: "Telefon", Status: gotrans.StatusDraft},
+		{Entity: "product", EntityID: 2, Field: "title", Locale: gotrans.LocaleDE, Value: "Tablet", Status: gotrans.StatusInReview},
+	}))
+
+	got, err := repo.GetTranslations(ctx, gotrans.LocaleDE, "product", []int{1})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, gotrans.StatusDraft, got[0].Status)
+
+	drafts, err := repo.TranslationsByStatus(ctx, "product", gotrans.LocaleNone, gotrans.StatusDraft)
+	require.NoError(t, err)
+	require.Len(t, drafts, 1)
+	require.Equal(t, "Telefon", drafts[0].Value)
+
+	published, err := repo.TranslationsByStatus(ctx, "product", gotrans.LocaleEN, gotrans.StatusPublished)
+	require.NoError(t, err)
+	require.Len(t, published, 1, "rows stored before the workflow are published")
+
+	plain := NewTranslationRepositoryWithOptions(db, Options{})
+	drafts, err = plain.TranslationsByStatus(ctx, "product", gotrans.LocaleDE, gotrans.StatusDraft)
+	require.NoError(t, err)
+	require.Empty(t, drafts)
+	got, err = plain.GetTranslations(ctx, gotrans.LocaleDE, "product", []int{1})
+	require.NoError(t, err)
+	require.Equal(t, gotrans.StatusPublished, got[0].Status, "without Workflow the status is ignored")
+}
@@ -0,0 +1,151 @@
+package gotrans
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type localeContextKey struct{}
+
+// WithLocale returns a copy of ctx carrying l. See LocaleFromContext.
+func WithLocale(ctx context.Context, l Locale) context.Context {
+	return context.WithValue(ctx, localeContextKey{}, l)
+}
+
+// LocaleFromContext returns the locale stored by WithLocale or
+// LocaleMiddleware, and whether there was one.
+func LocaleFromContext(ctx context.Context) (Locale, bool) {
+	l, ok := ctx.Value(localeContextKey{}).(Locale)
+	return l, ok && l != LocaleNone
+}
+
+// LocaleOrDefault returns the locale in ctx, or def when there is none.
+func LocaleOrDefault(ctx context.Context, def Locale) Locale {
+	if l, ok := LocaleFromContext(ctx); ok {
+		return l
+	}
+	return def
+}
+
+// maxAcceptLanguageRanges caps how many ranges of an Accept-Language header
+// are considered, so hostile headers stay cheap.
+const maxAcceptLanguageRanges = 16
+
+// LanguageRange is one entry of an Accept-Language header.
+type LanguageRange struct {
+	Tag     string // as sent, e.g. "en-GB" or "*"
+	Quality float64
+}
+
+// ParseAcceptLanguage parses an Accept-Language header into its ranges,
+// ordered by descending quality, keeping header order among equal qualities.
+// Ranges with q=0 and malformed entries are dropped.
+func ParseAcceptLanguage(header string) []LanguageRange {
+	var ranges []LanguageRange
+	for _, part := range strings.Split(header, ",") {
+		if len(ranges) == maxAcceptLanguageRanges {
+			break
+		}
+		tag, params, _ := strings.Cut(part, ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		q := 1.0
+		if name, value, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.TrimSpace(name) == "q" {
+			v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || v < 0 || v > 1 {
+				continue
+			}
+			q = v
+		}
+		if q > 0 {
+			ranges = append(ranges, LanguageRange{Tag: tag, Quality: q})
+		}
+	}
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].Quality > ranges[j].Quality })
+	return ranges
+}
+
+// NegotiateLocale picks the supported locale that best matches an
+// Accept-Language header, or def when none does. Each range, best first, is
+// matched exactly, then through its parents (en-GB matches a supported en),
+// then against supported variants of the same language (en matches a
+// supported en-US, the first one listed). "*" matches the first supported
+// locale. Ranges are compared with the supported tags as strings, in any
+// case and hyphen or underscore form; they are never parsed into locales.
+func NegotiateLocale(header string, supported []Locale, def Locale) Locale {
+	for _, r := range ParseAcceptLanguage(header) {
+		if r.Tag == "*" {
+			if len(supported) > 0 {
+				return supported[0]
+			}
+			continue
+		}
+		tag := strings.ReplaceAll(r.Tag, "_", "-")
+		for prefix := tag; prefix != ""; {
+			for _, s := range supported {
+				if strings.EqualFold(s.Code(), prefix) {
+					return s
+				}
+			}
+			i := strings.LastIndexByte(prefix, '-')
+			if i < 0 {
+				break
+			}
+			prefix = prefix[:i]
+		}
+		lang, _, _ := strings.Cut(tag, "-")
+		for _, s := range supported {
+			if strings.EqualFold(s.Base().Code(), lang) {
+				return s
+			}
+		}
+	}
+	return def
+}
+
+// LocaleMiddlewareOptions configures LocaleMiddleware.
+type LocaleMiddlewareOptions struct {
+	// Supported lists the locales the application serves, in order of
+	// preference for wildcard and same-language matches.
+	Supported []Locale
+	// Default is used when nothing matches. It defaults to Supported[0].
+	Default Locale
+	// QueryParameter, if set, names a query parameter, e.g. "lang", that
+	// overrides Accept-Language when it holds a supported locale.
+	QueryParameter string
+}
+
+// LocaleMiddleware negotiates the request locale from Accept-Language (see
+// NegotiateLocale) and stores it in the request context, where
+// LocaleFromContext and a translator with ContextLocale find it. It sets
+// Content-Language and adds Accept-Language to Vary.
+func LocaleMiddleware(opts LocaleMiddlewareOptions) func(http.Handler) http.Handler {
+	def := opts.Default
+	if def == LocaleNone && len(opts.Supported) > 0 {
+		def = opts.Supported[0]
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			locale := LocaleNone
+			if opts.QueryParameter != "" {
+				if v := r.URL.Query().Get(opts.QueryParameter); v != "" {
+					locale = NegotiateLocale(v, opts.Supported, LocaleNone)
+				}
+			}
+			if locale == LocaleNone {
+				locale = NegotiateLocale(r.Header.Get("Accept-Language"), opts.Supported, def)
+			}
+			w.Header().Add("Vary", "Accept-Language")
+			if locale != LocaleNone {
+				w.Header().Set("Content-Language", locale.String())
+				r = r.WithContext(WithLocale(r.Context(), locale))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
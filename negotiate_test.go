@@ -0,0 +1,94 @@
+package gotrans
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	require.Equal(t, []LanguageRange{
+		{"fr-CH", 1}, {"de", 1}, {"fr", 0.9}, {"*", 0.5},
+	}, ParseAcceptLanguage("fr-CH, fr;q=0.9, en;q=0, de, *;q=0.5, xx;q=2, ;q=1"))
+	require.Empty(t, ParseAcceptLanguage(""))
+}
+
+func TestNegotiateLocale(t *testing.T) {
+	supported := []Locale{LocaleEN, LocaleENGB, LocalePTBR, LocaleDE}
+	cases := []struct {
+		header string
+		want   Locale
+	}{
+		{"en-GB,en;q=0.8", LocaleENGB},
+		{"en-AU", LocaleEN},                 // region falls back to the base language
+		{"pt-PT,pt;q=0.9", LocalePTBR},      // same language, other region
+		{"fr;q=0.9, de-AT;q=0.8", LocaleDE}, // first unsupported, second via parent
+		{"de;q=0.1, en-US;q=0.5", LocaleEN},
+		{"fr, *;q=0.1", LocaleEN},
+		{"fr", LocaleDE},
+		{"", LocaleDE},
+	}
+	for _, c := range cases {
+		require.Equal(t, c.want, NegotiateLocale(c.header, supported, LocaleDE), c.header)
+	}
+}
+
+func TestNegotiateLocale_DoesNotRegisterTags(t *testing.T) {
+	size := len(AllLocales())
+	for i := range 200 {
+		header := fmt.Sprintf("de-%03d, zh-Hant-%03d;q=0.9, en-Latn-GB-x-%d;q=0.8, q%d", i, i, i, i)
+		require.Equal(t, LocaleDE, NegotiateLocale(header, []Locale{LocaleEN, LocaleDE}, LocaleEN), header)
+	}
+	require.Equal(t, LocaleENGB, NegotiateLocale("EN_gb-x-junk", []Locale{LocaleEN, LocaleENGB}, LocaleNone))
+	require.Len(t, AllLocales(), size)
+}
+
+func TestLocaleMiddleware(t *testing.T) {
+	var got Locale
+	h := LocaleMiddleware(LocaleMiddlewareOptions{
+		Supported:      []Locale{LocaleEN, LocaleFR},
+		QueryParameter: "lang",
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = LocaleFromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Language", "fr-CA,en;q=0.5")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	require.Equal(t, LocaleFR, got)
+	require.Equal(t, "fr", rec.Header().Get("Content-Language"))
+	require.Equal(t, "Accept-Language", rec.Header().Get("Vary"))
+
+	req = httptest.NewRequest(http.MethodGet, "/?lang=en", nil)
+	req.Header.Set("Accept-Language", "fr")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	require.Equal(t, LocaleEN, got, "query parameter overrides the header")
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/?lang=xx", nil))
+	require.Equal(t, LocaleEN, got, "default is the first supported locale")
+}
+
+func TestTranslator_ContextLocale(t *testing.T) {
+	repo := &mockRepo{translations: []Translation{
+		{Entity: "parameter", EntityID: 1, Field: "name", Locale: LocaleFR, Value: "Nom"},
+		{Entity: "parameter", EntityID: 2, Field: "name", Locale: LocaleEN, Value: "Name"},
+	}}
+	ctx := WithLocale(context.Background(), LocaleFR)
+	entities := []Parameter{{ID: 1}, {ID: 2, locale: LocaleEN}}
+
+	trans := NewTranslatorWithOptions(TranslatorOptions[Parameter]{Repository: repo, ContextLocale: true})
+	got, err := trans.LoadTranslations(ctx, entities)
+	require.NoError(t, err)
+	require.Equal(t, "Nom", got[0].Name)
+	require.Equal(t, LocaleNone, got[0].locale, "the entity keeps its locale")
+	require.Equal(t, "Name", got[1].Name, "an explicit locale wins over the context")
+
+	got, err = NewTranslator[Parameter](repo).LoadTranslations(ctx, []Parameter{{ID: 1}})
+	require.NoError(t, err)
+	require.Empty(t, got[0].Name, "without the option the context is ignored")
+}
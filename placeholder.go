@@ -0,0 +1,237 @@
+package gotrans
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// PlaceholderMode controls what PlaceholderValidator does with a mismatch.
+type PlaceholderMode uint8
+
+const (
+	// PlaceholderReject fails the save with a *ValidationError.
+	PlaceholderReject PlaceholderMode = iota
+	// PlaceholderWarn reports mismatches to OnWarning and lets the save proceed.
+	PlaceholderWarn
+)
+
+// PlaceholderValidator compares the placeholders of every saved value with
+// the same field in SourceLocale. Both ICU arguments ({name}, {n, plural, …})
+// and printf verbs (%s, %[1]d, %.2f) are checked; a value may not drop, add or
+// rename any of them.
+//
+// Source values come from the rows of the same save call when present and
+// otherwise from the repository. Values without a stored source are not
+// checked. Plural variants are compared with the same variant of the source,
+// or with its "other" variant.
+type PlaceholderValidator struct {
+	SourceLocale Locale
+	// Fields limits validation to these DB field IDs. Empty means every field.
+	Fields []string
+	Mode   PlaceholderMode
+	// OnWarning receives mismatches in PlaceholderWarn mode. Nil drops them.
+	OnWarning func(Violation)
+}
+
+// ValidateTranslations implements TranslationValidator.
+func (v PlaceholderValidator) ValidateTranslations(ctx context.Context, repo TranslationRepository, trs []Translation) error {
+	type sourceKey struct {
+		entity string
+		id     int
+		field  string
+	}
+	sources := make(map[sourceKey]string)
+	need := make(map[string][]int) // entity → IDs without a source in this batch
+	for _, tr := range trs {
+		if tr.Locale == v.SourceLocale {
+			sources[sourceKey{tr.Entity, tr.EntityID, tr.Field}] = tr.Value
+		}
+	}
+	for _, tr := range trs {
+		if tr.Locale == v.SourceLocale || tr.Value == "" || !matchField(v.Fields, tr.Field) {
+			continue
+		}
+		if _, ok := sources[sourceKey{tr.Entity, tr.EntityID, tr.Field}]; !ok {
+			need[tr.Entity] = append(need[tr.Entity], tr.EntityID)
+		}
+	}
+	for entity, ids := range need {
+		stored, err := repo.GetTranslations(ctx, v.SourceLocale, entity, uniqueInts(ids))
+		if err != nil {
+			return err
+		}
+		for _, tr := range stored {
+			k := sourceKey{entity, tr.EntityID, tr.Field}
+			if _, ok := sources[k]; !ok {
+				sources[k] = tr.Value
+			}
+		}
+	}
+
+	var violations []Violation
+	for _, tr := range trs {
+		if tr.Locale == v.SourceLocale || !matchField(v.Fields, tr.Field) {
+			continue
+		}
+		if tr.Value == "" {
+			continue // not translated yet; see Required for that
+		}
+		src, ok := sources[sourceKey{tr.Entity, tr.EntityID, tr.Field}]
+		if !ok {
+			base, _, plural := splitPluralFieldID(tr.Field)
+			if !plural {
+				continue
+			}
+			if src, ok = sources[sourceKey{tr.Entity, tr.EntityID, PluralFieldID(base, PluralOther)}]; !ok {
+				continue
+			}
+		}
+		if msg := diffPlaceholders(Placeholders(src), Placeholders(tr.Value)); msg != "" {
+			violations = append(violations, newViolation(tr, "placeholders", fmt.Sprintf("%s (source %s)", msg, v.SourceLocale), nil))
+		}
+	}
+	if len(violations) > 0 && v.Mode == PlaceholderWarn {
+		if v.OnWarning != nil {
+			for _, viol := range violations {
+				v.OnWarning(viol)
+			}
+		}
+		return nil
+	}
+	return violationsError(violations)
+}
+
+var (
+	printfVerbRe = regexp.MustCompile(`%(?:\[\d+\])?[-+#0]*(?:\d+|\*)?(?:\.(?:\d+|\*))?[a-zA-Z%]`)
+	icuArgRe     = regexp.MustCompile(`\{\s*([\p{L}\p{N}_]+)\s*[,}]`)
+)
+
+// Placeholders returns the placeholders of s: ICU arguments as "{name}" and
+// printf verbs as written ("%s", "%[2]d"). Repeated placeholders are listed
+// once per occurrence; inside a plural or select argument only the case with
+// the most occurrences counts, as a message renders a single case. "%%" is
+// not a placeholder.
+func Placeholders(s string) []string {
+	var out []string
+	for _, verb := range printfVerbRe.FindAllString(s, -1) {
+		if verb != "%%" {
+			out = append(out, verb)
+		}
+	}
+	if m, err := ParseMessage(s); err == nil {
+		var order []string
+		counts := argumentCounts(m.nodes, &order)
+		for _, name := range order {
+			for range counts[name] {
+				out = append(out, "{"+name+"}")
+			}
+		}
+		return out
+	}
+	// Not valid MessageFormat: fall back to a lexical scan for {name}.
+	for _, m := range icuArgRe.FindAllStringSubmatch(s, -1) {
+		out = append(out, "{"+m[1]+"}")
+	}
+	return out
+}
+
+// argumentCounts returns how often each argument occurs in nodes, taking the
+// largest count over the cases of a choice, and appends the names to order
+// on first appearance.
+func argumentCounts(nodes []msgNode, order *[]string) map[string]int {
+	counts := make(map[string]int)
+	add := func(name string, n int) {
+		if !slices.Contains(*order, name) {
+			*order = append(*order, name)
+		}
+		counts[name] += n
+	}
+	for _, n := range nodes {
+		switch n := n.(type) {
+		case argNode:
+			add(n.name, 1)
+		case choiceNode:
+			add(n.name, 1)
+			most := make(map[string]int)
+			for _, c := range n.cases {
+				for name, k := range argumentCounts(c.nodes, order) {
+					most[name] = max(most[name], k)
+				}
+			}
+			for name, k := range most {
+				counts[name] += k
+			}
+		}
+	}
+	return counts
+}
+
+// diffPlaceholders describes how got differs from want, or returns "" when
+// both hold the same placeholders. A missing and an extra placeholder of the
+// same style are reported together as a rename.
+func diffPlaceholders(want, got []string) string {
+	counts := make(map[string]int)
+	for _, p := range want {
+		counts[p]++
+	}
+	for _, p := range got {
+		counts[p]--
+	}
+	var missing, extra []string
+	for p, n := range counts {
+		for ; n > 0; n-- {
+			missing = append(missing, p)
+		}
+		for ; n < 0; n++ {
+			extra = append(extra, p)
+		}
+	}
+	if len(missing) == 0 && len(extra) == 0 {
+		return ""
+	}
+	sort.Strings(missing)
+	sort.Strings(extra)
+
+	var renamed []string
+	for i := 0; i < len(missing); i++ {
+		for j := 0; j < len(extra); j++ {
+			if isICUPlaceholder(missing[i]) == isICUPlaceholder(extra[j]) {
+				renamed = append(renamed, missing[i]+" → "+extra[j])
+				missing = append(missing[:i], missing[i+1:]...)
+				extra = append(extra[:j], extra[j+1:]...)
+				i--
+				break
+			}
+		}
+	}
+
+	var parts []string
+	if len(missing) > 0 {
+		parts = append(parts, "missing "+strings.Join(missing, ", "))
+	}
+	if len(extra) > 0 {
+		parts = append(parts, "extra "+strings.Join(extra, ", "))
+	}
+	if len(renamed) > 0 {
+		parts = append(parts, "renamed "+strings.Join(renamed, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func isICUPlaceholder(p string) bool { return strings.HasPrefix(p, "{") }
+
+func uniqueInts(ids []int) []int {
+	seen := make(map[int]struct{}, len(ids))
+	out := ids[:0:0]
+	for _, id := range ids {
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			out = append(out, id)
+		}
+	}
+	return out
+}
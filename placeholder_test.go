@@ -0,0 +1,112 @@
+package gotrans
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaceholders(t *testing.T) {
+	require.Equal(t, []string{"%s", "%[2]d", "%.2f"}, Placeholders("%s has %[2]d items at %.2f, 100%% off"))
+	require.Equal(t, []string{"{name}", "{count}"}, Placeholders("Hi {name}, {count, plural, one {# item} other {# items}}"))
+	require.Equal(t, []string{"{name}"}, Placeholders("broken {name} {"), "lexical fallback for invalid patterns")
+	require.Empty(t, Placeholders("50 % de réduction"))
+	require.Equal(t, []string{"{name}", "{name}"}, Placeholders("{name}, oh {name}!"))
+	require.Equal(t, []string{"{n}", "{name}"}, Placeholders("{n, plural, one {{name}: # item} few {{name}: # items} other {{name}: # items}}"),
+		"a choice counts its busiest case, not every case")
+}
+
+func TestPlaceholderValidator_RepeatedPlaceholders(t *testing.T) {
+	repo := &mockRepo{translations: []Translation{
+		{Entity: "parameter", EntityID: 1, Field: "name", Locale: LocaleEN, Value: "{name}, oh {name}!"},
+		{Entity: "parameter", EntityID: 2, Field: "name", Locale: LocaleEN, Value: "Hi {name}"},
+	}}
+	trans := NewTranslatorWithOptions(TranslatorOptions[Parameter]{
+		Repository: repo,
+		Validators: []TranslationValidator{PlaceholderValidator{SourceLocale: LocaleEN}},
+	})
+
+	err := trans.SaveTranslations(context.Background(), []Parameter{
+		{ID: 1, locale: LocaleFR, Name: "{name} !"},
+		{ID: 2, locale: LocaleFR, Name: "Salut {name} {name}"},
+	})
+	var ve *ValidationError
+	require.ErrorAs(t, err, &ve)
+	require.Equal(t, []Violation{
+		{Entity: "parameter", EntityID: 1, Field: "name", Locale: LocaleFR, Rule: "placeholders", Message: "missing {name} (source en)"},
+		{Entity: "parameter", EntityID: 2, Field: "name", Locale: LocaleFR, Rule: "placeholders", Message: "extra {name} (source en)"},
+	}, ve.Violations)
+}
+
+func TestDiffPlaceholders(t *testing.T) {
+	require.Empty(t, diffPlaceholders([]string{"{a}", "%s"}, []string{"%s", "{a}"}))
+	require.Equal(t, "missing {count}", diffPlaceholders([]string{"{name}", "{count}"}, []string{"{name}"}))
+	require.Equal(t, "extra %s", diffPlaceholders(nil, []string{"%s"}))
+	require.Equal(t, "renamed {name} → {nom}", diffPlaceholders([]string{"{name}"}, []string{"{nom}"}))
+	require.Equal(t, "missing %s", diffPlaceholders([]string{"%s", "%s"}, []string{"%s"}))
+}
+
+func TestPlaceholderValidator_RejectsAgainstStoredSource(t *testing.T) {
+	repo := &mockRepo{translations: []Translation{
+		{Entity: "parameter", EntityID: 1, Field: "name", Locale: LocaleEN, Value: "Hello {name}"},
+		{Entity: "parameter", EntityID: 1, Field: "description", Locale: LocaleEN, Value: "%d left"},
+		{Entity: "parameter", EntityID: 2, Field: "name", Locale: LocaleEN, Value: "Bye {name}"},
+	}}
+	trans := NewTranslatorWithOptions(TranslatorOptions[Parameter]{
+		Repository: repo,
+		Validators: []TranslationValidator{PlaceholderValidator{SourceLocale: LocaleEN}},
+	})
+
+	err := trans.SaveTranslations(context.Background(), []Parameter{
+		{ID: 1, locale: LocaleFR, Name: "Bonjour {nom}", Description: "%d restants"},
+		{ID: 2, locale: LocaleFR, Name: "Au revoir"},
+		{ID: 3, locale: LocaleFR, Name: "{no source}"},
+	})
+	var ve *ValidationError
+	require.ErrorAs(t, err, &ve)
+	require.Equal(t, []Violation{
+		{Entity: "parameter", EntityID: 1, Field: "name", Locale: LocaleFR, Rule: "placeholders", Message: "renamed {name} → {nom} (source en)"},
+		{Entity: "parameter", EntityID: 2, Field: "name", Locale: LocaleFR, Rule: "placeholders", Message: "missing {name} (source en)"},
+	}, ve.Violations)
+	require.Empty(t, repo.saved)
+}
+
+func TestPlaceholderValidator_SourceInSameBatch(t *testing.T) {
+	repo := &mockRepo{}
+	trans := NewTranslatorWithOptions(TranslatorOptions[Parameter]{
+		Repository: repo,
+		Validators: []TranslationValidator{PlaceholderValidator{SourceLocale: LocaleEN, Fields: []string{"name"}}},
+	})
+
+	err := trans.SaveTranslations(context.Background(), []Parameter{
+		{ID: 1, locale: LocaleEN, Name: "{count} items"},
+		{ID: 1, locale: LocaleDE, Name: "{count} Artikel", Description: "%s"},
+	})
+	require.NoError(t, err)
+	require.Len(t, repo.saved, 4)
+}
+
+func TestPlaceholderValidator_WarnMode(t *testing.T) {
+	repo := &mockRepo{translations: []Translation{
+		{Entity: "offer", EntityID: 1, Field: "stock#one", Locale: LocaleEN, Value: "{count} item"},
+		{Entity: "offer", EntityID: 1, Field: "stock#other", Locale: LocaleEN, Value: "{count} items"},
+	}}
+	var warnings []Violation
+	trans := NewTranslatorWithOptions(TranslatorOptions[Offer]{
+		Repository: repo,
+		Validators: []TranslationValidator{PlaceholderValidator{
+			SourceLocale: LocaleEN,
+			Mode:         PlaceholderWarn,
+			OnWarning:    func(v Violation) { warnings = append(warnings, v) },
+		}},
+	})
+
+	err := trans.SaveTranslations(context.Background(), []Offer{
+		{ID: 1, locale: LocaleRU, Stock: PluralText{PluralOne: "{count} товар", PluralFew: "товара"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, repo.saved, 3, "warnings do not block the save")
+	require.Len(t, warnings, 1)
+	require.Equal(t, "stock#few", warnings[0].Field, "plural variants without a source variant compare with other")
+}
@@ -0,0 +1,436 @@
+package gotrans
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// PluralCategory is a CLDR plural category.
+type PluralCategory string
+
+const (
+	PluralZero  PluralCategory = "zero"
+	PluralOne   PluralCategory = "one"
+	PluralTwo   PluralCategory = "two"
+	PluralFew   PluralCategory = "few"
+	PluralMany  PluralCategory = "many"
+	PluralOther PluralCategory = "other"
+)
+
+// ErrInvalidPluralOperand is returned by ParsePluralOperands for malformed numbers.
+var ErrInvalidPluralOperand = errors.New("invalid plural operand")
+
+// ErrUnknownPluralCategory is returned when saving a PluralText variant whose
+// category the entity's locale doesn't use, e.g. "few" in English.
+var ErrUnknownPluralCategory = errors.New("unknown plural category")
+
+// PluralOperands are the CLDR plural operands of a number.
+// See https://unicode.org/reports/tr35/tr35-numbers.html#Operands.
+type PluralOperands struct {
+	I uint64 // integer digits of n
+	V int    // number of visible fraction digits, with trailing zeros
+	W int    // number of visible fraction digits, without trailing zeros
+	F uint64 // visible fraction digits, with trailing zeros
+	T uint64 // visible fraction digits, without trailing zeros
+}
+
+// IntOperands returns the operands of an integer count. The sign is ignored.
+func IntOperands(n int) PluralOperands {
+	if n < 0 {
+		n = -n
+	}
+	return PluralOperands{I: uint64(n)}
+}
+
+// ParsePluralOperands returns the operands of a decimal number as written,
+// e.g. "1", "1.0" and "1.50". Visible trailing zeros matter: in English
+// "1" is one but "1.0" is other.
+func ParsePluralOperands(number string) (PluralOperands, error) {
+	s := strings.TrimPrefix(strings.TrimSpace(number), "-")
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if intPart == "" {
+		return PluralOperands{}, ErrInvalidPluralOperand
+	}
+	var o PluralOperands
+	var err error
+	if o.I, err = strconv.ParseUint(intPart, 10, 64); err != nil {
+		return PluralOperands{}, ErrInvalidPluralOperand
+	}
+	if fracPart == "" {
+		return o, nil
+	}
+	if o.F, err = strconv.ParseUint(fracPart, 10, 64); err != nil {
+		return PluralOperands{}, ErrInvalidPluralOperand
+	}
+	o.V = len(fracPart)
+	trimmed := strings.TrimRight(fracPart, "0")
+	o.W = len(trimmed)
+	if trimmed != "" {
+		o.T, _ = strconv.ParseUint(trimmed, 10, 64)
+	}
+	return o, nil
+}
+
+// isInt reports whether n has no non-zero fraction digits.
+func (o PluralOperands) isInt() bool { return o.T == 0 }
+
+// nIs reports whether n equals x exactly.
+func (o PluralOperands) nIs(x uint64) bool { return o.isInt() && o.I == x }
+
+// nModIn reports whether n % mod is within [lo, hi]. A non-integer n never matches.
+func (o PluralOperands) nModIn(mod, lo, hi uint64) bool {
+	return o.isInt() && inRange(o.I%mod, lo, hi)
+}
+
+func inRange(x, lo, hi uint64) bool { return x >= lo && x <= hi }
+
+// pluralRule maps operands to a category for one language.
+type pluralRule func(o PluralOperands) PluralCategory
+
+// pluralRuleSet is the cardinal plural rule of a language and the categories it uses.
+type pluralRuleSet struct {
+	categories []PluralCategory
+	rule       pluralRule
+}
+
+var (
+	categoriesOther          = []PluralCategory{PluralOther}
+	categoriesOneOther       = []PluralCategory{PluralOne, PluralOther}
+	categoriesOneManyOther   = []PluralCategory{PluralOne, PluralMany, PluralOther}
+	categoriesOneFewOther    = []PluralCategory{PluralOne, PluralFew, PluralOther}
+	categoriesOneFewMany     = []PluralCategory{PluralOne, PluralFew, PluralMany, PluralOther}
+	categoriesZeroOneOther   = []PluralCategory{PluralZero, PluralOne, PluralOther}
+	categoriesOneTwoOther    = []PluralCategory{PluralOne, PluralTwo, PluralOther}
+	categoriesOneTwoFewOther = []PluralCategory{PluralOne, PluralTwo, PluralFew, PluralOther}
+	categoriesAll            = []PluralCategory{PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther}
+)
+
+// Shared CLDR rules, named after a representative language.
+
+func ruleOther(PluralOperands) PluralCategory { return PluralOther }
+
+// n = 1
+func ruleNIsOne(o PluralOperands) PluralCategory {
+	if o.nIs(1) {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// i = 1 and v = 0 (English, German, Dutch, ...)
+func ruleEnglish(o PluralOperands) PluralCategory {
+	if o.I == 1 && o.V == 0 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// i = 1 and v = 0; many: i != 0 and i % 1000000 = 0 and v = 0 (Italian,
+// European Portuguese)
+func ruleItalian(o PluralOperands) PluralCategory {
+	if o.I == 1 && o.V == 0 {
+		return PluralOne
+	}
+	if isMillions(o) {
+		return PluralMany
+	}
+	return PluralOther
+}
+
+// one: i = 0,1; many: i != 0 and i % 1000000 = 0 and v = 0 (French,
+// Portuguese except pt-PT)
+func ruleFrench(o PluralOperands) PluralCategory {
+	if o.I <= 1 {
+		return PluralOne
+	}
+	if isMillions(o) {
+		return PluralMany
+	}
+	return PluralOther
+}
+
+// one: n = 1; many: i != 0 and i % 1000000 = 0 and v = 0 (Spanish)
+func ruleSpanish(o PluralOperands) PluralCategory {
+	if o.nIs(1) {
+		return PluralOne
+	}
+	if isMillions(o) {
+		return PluralMany
+	}
+	return PluralOther
+}
+
+func isMillions(o PluralOperands) bool {
+	return o.V == 0 && o.I != 0 && o.I%1000000 == 0
+}
+
+// one: n = 1 or t != 0 and i = 0,1 (Danish)
+func ruleDanish(o PluralOperands) PluralCategory {
+	if o.nIs(1) || o.T != 0 && o.I <= 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// Bosnian, Croatian, Serbian.
+func ruleSerbian(o PluralOperands) PluralCategory {
+	i10, i100 := o.I%10, o.I%100
+	f10, f100 := o.F%10, o.F%100
+	if o.V == 0 && i10 == 1 && i100 != 11 || f10 == 1 && f100 != 11 {
+		return PluralOne
+	}
+	if o.V == 0 && inRange(i10, 2, 4) && !inRange(i100, 12, 14) ||
+		inRange(f10, 2, 4) && !inRange(f100, 12, 14) {
+		return PluralFew
+	}
+	return PluralOther
+}
+
+// Russian, Ukrainian.
+func ruleRussian(o PluralOperands) PluralCategory {
+	if o.V != 0 {
+		return PluralOther
+	}
+	i10, i100 := o.I%10, o.I%100
+	switch {
+	case i10 == 1 && i100 != 11:
+		return PluralOne
+	case inRange(i10, 2, 4) && !inRange(i100, 12, 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+// Czech, Slovak.
+func ruleCzech(o PluralOperands) PluralCategory {
+	switch {
+	case o.V != 0:
+		return PluralMany
+	case o.I == 1:
+		return PluralOne
+	case inRange(o.I, 2, 4):
+		return PluralFew
+	}
+	return PluralOther
+}
+
+func rulePolish(o PluralOperands) PluralCategory {
+	if o.V != 0 {
+		return PluralOther
+	}
+	i10, i100 := o.I%10, o.I%100
+	switch {
+	case o.I == 1:
+		return PluralOne
+	case inRange(i10, 2, 4) && !inRange(i100, 12, 14):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+func ruleArabic(o PluralOperands) PluralCategory {
+	switch {
+	case o.nIs(0):
+		return PluralZero
+	case o.nIs(1):
+		return PluralOne
+	case o.nIs(2):
+		return PluralTwo
+	case o.nModIn(100, 3, 10):
+		return PluralFew
+	case o.nModIn(100, 11, 99):
+		return PluralMany
+	}
+	return PluralOther
+}
+
+func ruleHebrew(o PluralOperands) PluralCategory {
+	switch {
+	case o.I == 1 && o.V == 0 || o.I == 0 && o.V != 0:
+		return PluralOne
+	case o.I == 2 && o.V == 0:
+		return PluralTwo
+	}
+	return PluralOther
+}
+
+func ruleLatvian(o PluralOperands) PluralCategory {
+	f10, f100 := o.F%10, o.F%100
+	if o.nModIn(10, 0, 0) || o.nModIn(100, 11, 19) || o.V == 2 && inRange(f100, 11, 19) {
+		return PluralZero
+	}
+	if o.nModIn(10, 1, 1) && !o.nModIn(100, 11, 11) ||
+		o.V == 2 && f10 == 1 && f100 != 11 ||
+		o.V != 2 && f10 == 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+func ruleLithuanian(o PluralOperands) PluralCategory {
+	switch {
+	case o.F != 0:
+		return PluralMany
+	case o.nModIn(10, 1, 1) && !o.nModIn(100, 11, 19):
+		return PluralOne
+	case o.nModIn(10, 2, 9) && !o.nModIn(100, 11, 19):
+		return PluralFew
+	}
+	return PluralOther
+}
+
+func ruleMacedonian(o PluralOperands) PluralCategory {
+	if o.V == 0 && o.I%10 == 1 && o.I%100 != 11 || o.F%10 == 1 && o.F%100 != 11 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+func ruleRomanian(o PluralOperands) PluralCategory {
+	switch {
+	case o.I == 1 && o.V == 0:
+		return PluralOne
+	case o.V != 0 || o.nIs(0) || !o.nIs(1) && o.nModIn(100, 1, 19):
+		return PluralFew
+	}
+	return PluralOther
+}
+
+func ruleSlovenian(o PluralOperands) PluralCategory {
+	if o.V != 0 {
+		return PluralFew
+	}
+	switch i100 := o.I % 100; {
+	case i100 == 1:
+		return PluralOne
+	case i100 == 2:
+		return PluralTwo
+	case inRange(i100, 3, 4):
+		return PluralFew
+	}
+	return PluralOther
+}
+
+// pluralRules maps a language code to its CLDR cardinal rule set. Every
+// built-in locale is covered by its base language; pluralRuleSetFor tries
+// the full tag first, so regional tags with rules of their own come last.
+var pluralRules = map[string]pluralRuleSet{
+	"ar": {categoriesAll, ruleArabic},
+	"az": {categoriesOneOther, ruleNIsOne},
+	"bg": {categoriesOneOther, ruleNIsOne},
+	"bs": {categoriesOneFewOther, ruleSerbian},
+	"cs": {categoriesOneFewMany, ruleCzech},
+	"da": {categoriesOneOther, ruleDanish},
+	"de": {categoriesOneOther, ruleEnglish},
+	"el": {categoriesOneOther, ruleNIsOne},
+	"en": {categoriesOneOther, ruleEnglish},
+	"es": {categoriesOneManyOther, ruleSpanish},
+	"et": {categoriesOneOther, ruleEnglish},
+	"fi": {categoriesOneOther, ruleEnglish},
+	"fr": {categoriesOneManyOther, ruleFrench},
+	"he": {categoriesOneTwoOther, ruleHebrew},
+	"hr": {categoriesOneFewOther, ruleSerbian},
+	"hu": {categoriesOneOther, ruleNIsOne},
+	"id": {categoriesOther, ruleOther},
+	"it": {categoriesOneManyOther, ruleItalian},
+	"ja": {categoriesOther, ruleOther},
+	"ka": {categoriesOneOther, ruleNIsOne},
+	"kk": {categoriesOneOther, ruleNIsOne},
+	"ko": {categoriesOther, ruleOther},
+	"lt": {categoriesOneFewMany, ruleLithuanian},
+	"lv": {categoriesZeroOneOther, ruleLatvian},
+	"mk": {categoriesOneOther, ruleMacedonian},
+	"nl": {categoriesOneOther, ruleEnglish},
+	"no": {categoriesOneOther, ruleNIsOne},
+	"pl": {categoriesOneFewMany, rulePolish},
+	"pt": {categoriesOneManyOther, ruleFrench},
+	"ro": {categoriesOneFewOther, ruleRomanian},
+	"ru": {categoriesOneFewMany, ruleRussian},
+	"sk": {categoriesOneFewMany, ruleCzech},
+	"sl": {categoriesOneTwoFewOther, ruleSlovenian},
+	"sq": {categoriesOneOther, ruleNIsOne},
+	"sr": {categoriesOneFewOther, ruleSerbian},
+	"sv": {categoriesOneOther, ruleEnglish},
+	"th": {categoriesOther, ruleOther},
+	"tr": {categoriesOneOther, ruleNIsOne},
+	"uk": {categoriesOneFewMany, ruleRussian},
+	"vi": {categoriesOther, ruleOther},
+	"zh": {categoriesOther, ruleOther},
+
+	"pt-PT": {categoriesOneManyOther, ruleItalian},
+}
+
+// pluralRuleSetFor returns the rule set of l or its nearest parent, registered
+// rules first, or "other only" for unknown locales.
+func pluralRuleSetFor(l Locale) pluralRuleSet {
+	for ; l != LocaleNone; l = l.Parent() {
+		if info, _ := l.info(); info.plural != nil {
+			return *info.plural
+		}
+		if rs, ok := pluralRules[l.Code()]; ok {
+			return rs
+		}
+	}
+	return pluralRuleSet{categoriesOther, ruleOther}
+}
+
+// PluralCategoryOf returns the CLDR cardinal plural category of an integer
+// count in locale l. Unknown locales always yield PluralOther.
+func PluralCategoryOf(l Locale, count int) PluralCategory {
+	return pluralRuleSetFor(l).rule(IntOperands(count))
+}
+
+// PluralCategoryOfOperands is PluralCategoryOf for decimal numbers, see
+// ParsePluralOperands.
+func PluralCategoryOfOperands(l Locale, o PluralOperands) PluralCategory {
+	return pluralRuleSetFor(l).rule(o)
+}
+
+// -----------------------------------------------------------------------------
+// PluralText — plural-aware translatable field
+// -----------------------------------------------------------------------------
+
+// PluralFieldSeparator joins a DB field ID and a plural category in storage:
+// a PluralText field "title" is stored as rows "title#one", "title#few", ...
+const PluralFieldSeparator = "#"
+
+// PluralFieldID returns the storage field ID of one plural variant.
+func PluralFieldID(field string, c PluralCategory) string {
+	return field + PluralFieldSeparator + string(c)
+}
+
+// splitPluralFieldID splits "title#few" into ("title", "few", true).
+func splitPluralFieldID(id string) (string, PluralCategory, bool) {
+	field, cat, ok := strings.Cut(id, PluralFieldSeparator)
+	return field, PluralCategory(cat), ok
+}
+
+// PluralText holds the plural variants of a translatable text, keyed by
+// category. Use it as a struct field type mapped in TranslatableFields; each
+// variant is stored as its own row (see PluralFieldID).
+//
+//	type Product struct {
+//		StockLabel gotrans.PluralText // {"one": "{count} item left", "other": "{count} items left"}
+//	}
+type PluralText map[PluralCategory]string
+
+// Select returns the variant for count in locale l, falling back to PluralOther.
+func (p PluralText) Select(l Locale, count int) string {
+	return p.selectCategory(PluralCategoryOf(l, count))
+}
+
+// Render selects the variant for count in locale l and replaces every
+// "{count}" placeholder with the number.
+func (p PluralText) Render(l Locale, count int) string {
+	return strings.ReplaceAll(p.Select(l, count), "{count}", strconv.Itoa(count))
+}
+
+func (p PluralText) selectCategory(c PluralCategory) string {
+	if s, ok := p[c]; ok {
+		return s
+	}
+	return p[PluralOther]
+}
@@ -0,0 +1,204 @@
+package gotrans
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type Offer struct {
+	ID     int
+	locale Locale
+	Title  string
+	Stock  PluralText
+}
+
+func (o Offer) TranslationEntityID() int        { return o.ID }
+func (o Offer) TranslationEntityLocale() Locale { return o.locale }
+func (o Offer) TranslationEntityName() string   { return "offer" }
+func (o Offer) TranslatableFields() map[string]string {
+	return map[string]string{"Title": "title", "Stock": "stock"}
+}
+
+func TestPluralCategoryOf(t *testing.T) {
+	cases := []struct {
+		locale Locale
+		count  int
+		want   PluralCategory
+	}{
+		{LocaleEN, 0, PluralOther},
+		{LocaleEN, 1, PluralOne},
+		{LocaleEN, 2, PluralOther},
+		{LocaleRU, 1, PluralOne},
+		{LocaleRU, 3, PluralFew},
+		{LocaleRU, 5, PluralMany},
+		{LocaleRU, 11, PluralMany},
+		{LocaleRU, 21, PluralOne},
+		{LocaleRU, 22, PluralFew},
+		{LocaleUK, 112, PluralMany},
+		{LocalePL, 1, PluralOne},
+		{LocalePL, 22, PluralFew},
+		{LocalePL, 25, PluralMany},
+		{LocaleCS, 3, PluralFew},
+		{LocaleCS, 5, PluralOther},
+		{LocaleFR, 0, PluralOne},
+		{LocaleFR, 2, PluralOther},
+		{LocaleFR, 1000000, PluralMany},
+		{LocalePT, 0, PluralOne},
+		{LocalePTBR, 0, PluralOne},
+		{LocalePTPT, 0, PluralOther},
+		{LocalePTPT, 1, PluralOne},
+		{LocalePTPT, 2, PluralOther},
+		{LocalePTPT, 1000000, PluralMany},
+		{LocaleAR, 0, PluralZero},
+		{LocaleAR, 2, PluralTwo},
+		{LocaleAR, 105, PluralFew},
+		{LocaleAR, 111, PluralMany},
+		{LocaleAR, 100, PluralOther},
+		{LocaleSL, 102, PluralTwo},
+		{LocaleSL, 104, PluralFew},
+		{LocaleHE, 2, PluralTwo},
+		{LocaleLV, 10, PluralZero},
+		{LocaleLV, 21, PluralOne},
+		{LocaleLT, 12, PluralOther},
+		{LocaleRO, 19, PluralFew},
+		{LocaleRO, 20, PluralOther},
+		{LocaleJA, 1, PluralOther},
+		{LocaleEN, -1, PluralOne},
+		{LocaleNone, 1, PluralOther},
+	}
+	for _, c := range cases {
+		require.Equal(t, c.want, PluralCategoryOf(c.locale, c.count), "%s %d", c.locale, c.count)
+	}
+}
+
+func TestPluralCategoryOfOperands_Decimals(t *testing.T) {
+	for _, c := range []struct {
+		locale Locale
+		number string
+		want   PluralCategory
+	}{
+		{LocaleEN, "1", PluralOne},
+		{LocaleEN, "1.0", PluralOther},
+		{LocaleRU, "1.5", PluralOther},
+		{LocaleCS, "1.5", PluralMany},
+		{LocaleLT, "0.1", PluralMany},
+		{LocaleDA, "0.5", PluralOne},
+		{LocaleHR, "0.1", PluralOne},
+		{LocalePT, "1.5", PluralOne},
+		{LocalePTPT, "1.5", PluralOther},
+		{LocalePTPT, "1.0", PluralOther},
+	} {
+		o, err := ParsePluralOperands(c.number)
+		require.NoError(t, err)
+		require.Equal(t, c.want, PluralCategoryOfOperands(c.locale, o), "%s %s", c.locale, c.number)
+	}
+
+	_, err := ParsePluralOperands("1.x")
+	require.ErrorIs(t, err, ErrInvalidPluralOperand)
+}
+
+func TestPluralRules_CoverEveryLocale(t *testing.T) {
+	for l := range languages { // built-in locales; registered ones may have none
+		_, ok := pluralRules[l.Base().Code()]
+		require.True(t, ok, "no plural rule for %s", l)
+		rs := pluralRuleSetFor(l)
+		require.Contains(t, rs.categories, PluralOther)
+		for n := 0; n < 200; n++ {
+			require.Contains(t, rs.categories, rs.rule(IntOperands(n)), "%s %d", l, n)
+		}
+	}
+}
+
+func TestPluralText_Render(t *testing.T) {
+	p := PluralText{
+		PluralOne:   "{count} товар",
+		PluralFew:   "{count} товара",
+		PluralMany:  "{count} товаров",
+		PluralOther: "{count} товара",
+	}
+	require.Equal(t, "21 товар", p.Render(LocaleRU, 21))
+	require.Equal(t, "3 товара", p.Render(LocaleRU, 3))
+	require.Equal(t, "5 товаров", p.Render(LocaleRU, 5))
+
+	// Missing categories fall back to "other".
+	en := PluralText{PluralOther: "{count} items"}
+	require.Equal(t, "1 items", en.Render(LocaleEN, 1))
+	require.Equal(t, "", PluralText(nil).Render(LocaleEN, 1))
+}
+
+func TestPlural_SaveAndLoad(t *testing.T) {
+	repo := &mockRepo{}
+	trans := NewTranslator[Offer](repo)
+
+	err := trans.SaveTranslations(context.Background(), []Offer{
+		{ID: 1, locale: LocaleUK, Title: "Чай", Stock: PluralText{PluralOne: "{count} штука", PluralFew: "{count} штуки", PluralMany: "{count} штук"}},
+		{ID: 2, locale: LocaleUK, Title: "Кава"}, // nil PluralText → no rows
+	})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []Translation{
+		{Entity: "offer", EntityID: 1, Field: "title", Locale: LocaleUK, Value: "Чай"},
+		{Entity: "offer", EntityID: 1, Field: "stock#few", Locale: LocaleUK, Value: "{count} штуки"},
+		{Entity: "offer", EntityID: 1, Field: "stock#many", Locale: LocaleUK, Value: "{count} штук"},
+		{Entity: "offer", EntityID: 1, Field: "stock#one", Locale: LocaleUK, Value: "{count} штука"},
+		{Entity: "offer", EntityID: 2, Field: "title", Locale: LocaleUK, Value: "Кава"},
+	}, repo.saved)
+
+	repo.translations = repo.saved
+	out, err := trans.LoadTranslations(context.Background(), []Offer{{ID: 1, locale: LocaleUK}})
+	require.NoError(t, err)
+	require.Equal(t, "Чай", out[0].Title)
+	require.Equal(t, "5 штук", out[0].Stock.Render(LocaleUK, 5))
+	require.Equal(t, "22 штуки", out[0].Stock.Render(LocaleUK, 22))
+}
+
+func TestPlural_FallbackDoesNotMixLocales(t *testing.T) {
+	repo := &mockRepo{translations: []Translation{
+		{Entity: "offer", EntityID: 1, Field: "stock#other", Locale: LocaleFR, Value: "{count} articles"},
+		{Entity: "offer", EntityID: 1, Field: "stock#one", Locale: LocaleEN, Value: "{count} item"},
+		{Entity: "offer", EntityID: 1, Field: "stock#other", Locale: LocaleEN, Value: "{count} items"},
+	}}
+	trans := NewTranslatorWithOptions(TranslatorOptions[Offer]{Repository: repo, FallbackLocales: []Locale{LocaleEN}})
+
+	out, report, err := trans.LoadTranslationsWithReport(context.Background(), []Offer{{ID: 1, locale: LocaleFR}})
+	require.NoError(t, err)
+	require.Equal(t, PluralText{PluralOther: "{count} articles"}, out[0].Stock)
+	require.Equal(t, LocaleFR, report.Entities[0].Fields["stock"].Locale)
+	require.Equal(t, []string{"title"}, report.Entities[0].Unresolved)
+}
+
+func TestPlural_MaskAndDelete(t *testing.T) {
+	repo := &mockRepo{saved: []Translation{
+		{Entity: "offer", EntityID: 1, Field: "title", Locale: LocaleEN, Value: "Tea"},
+		{Entity: "offer", EntityID: 1, Field: "stock#one", Locale: LocaleEN, Value: "{count} cup"},
+		{Entity: "offer", EntityID: 1, Field: "stock#other", Locale: LocaleEN, Value: "{count} cups"},
+	}}
+	trans := NewTranslator[Offer](repo)
+
+	err := trans.SaveTranslationsWithOptions(context.Background(),
+		[]Offer{{ID: 1, locale: LocaleEN, Title: "ignored", Stock: PluralText{PluralOther: "{count} mugs"}}},
+		SaveOptions{Fields: []string{"stock"}})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []Translation{
+		{Entity: "offer", EntityID: 1, Field: "title", Locale: LocaleEN, Value: "Tea"},
+		{Entity: "offer", EntityID: 1, Field: "stock#other", Locale: LocaleEN, Value: "{count} mugs"},
+	}, repo.saved, "variants the saved PluralText no longer has are deleted")
+
+	require.NoError(t, trans.DeleteTranslations(context.Background(), LocaleEN, []int{1}, []string{"stock"}))
+	require.Equal(t, []Translation{
+		{Entity: "offer", EntityID: 1, Field: "title", Locale: LocaleEN, Value: "Tea"},
+	}, repo.saved)
+}
+
+func TestPlural_RejectsUnknownCategories(t *testing.T) {
+	repo := &mockRepo{}
+	trans := NewTranslator[Offer](repo)
+
+	err := trans.SaveTranslations(context.Background(), []Offer{
+		{ID: 1, locale: LocaleEN, Stock: PluralText{PluralFew: "{count} items", PluralOther: "{count} items"}},
+	})
+	require.ErrorIs(t, err, ErrUnknownPluralCategory)
+	require.Equal(t, KindValidation, KindOf(err))
+	require.Empty(t, repo.saved)
+}
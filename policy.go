@@ -0,0 +1,58 @@
+package gotrans
+
+// EmptyValuePolicy controls how SaveTranslations treats fields whose value is
+// the empty string. Fields without any value (nil *string, nil []string, null
+// wrappers) never produce a row regardless of the policy.
+type EmptyValuePolicy uint8
+
+const (
+	// EmptyValueWrite stores "" like any other value, overwriting existing text.
+	// This is the default and matches the behaviour before policies existed.
+	EmptyValueWrite EmptyValuePolicy = iota
+	// EmptyValueSkip leaves the stored translation untouched, so saving a
+	// partially filled entity doesn't erase fields the caller didn't fill in.
+	EmptyValueSkip
+	// EmptyValueDelete removes the stored translation for the empty field.
+	EmptyValueDelete
+)
+
+// SaveOptions are per-call options for Translator.SaveTranslationsWithOptions.
+type SaveOptions struct {
+	// Fields restricts the save to these DB field IDs (e.g. "title").
+	// Other fields are neither written nor deleted. Empty means all fields.
+	Fields []string
+	// Status is stored with every written translation. The zero value,
+	// StatusPublished, makes the values live immediately; save with
+	// StatusDraft to start an editorial workflow. See TransitionTranslations.
+	Status TranslationStatus
+	// Conditional fails the save with a *VersionConflictError if any written
+	// translation changed since the entity was loaded, according to the
+	// versions it carries (see TranslationVersioned). Fields the load found
+	// nothing for must still not exist. Deletes made under EmptyValueDelete
+	// are checked too. Every version is checked before the first write, and
+	// each repository call checks its own rows again; see
+	// VersionConflictError for what a concurrent change can still leave
+	// written. Needs a repository that tracks versions; others accept every
+	// write.
+	Conditional bool
+}
+
+// MissingFieldPolicy controls what LoadTranslations does with mapped fields
+// that have no stored translation for the entity's locale.
+type MissingFieldPolicy uint8
+
+const (
+	// MissingFieldKeep leaves the field as it was, e.g. source-language text
+	// pre-filled by the caller. This is the default.
+	MissingFieldKeep MissingFieldPolicy = iota
+	// MissingFieldZero resets the field to its zero value, so stale data from
+	// a reused struct never leaks through.
+	MissingFieldZero
+	// MissingFieldPlaceholder fills the field with a visible marker such as
+	// "[missing:title]", useful for QA builds.
+	MissingFieldPlaceholder
+)
+
+// DefaultMissingPlaceholderFormat is the marker used by MissingFieldPlaceholder
+// unless TranslatorOptions.MissingPlaceholderFormat overrides it.
+const DefaultMissingPlaceholderFormat = "[missing:%s]"
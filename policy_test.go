@@ -0,0 +1,164 @@
+package gotrans
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func seededRepo() *mockRepo {
+	return &mockRepo{saved: []Translation{
+		{Entity: "parameter", EntityID: 1, Field: "name", Locale: LocaleFR, Value: "Nom"},
+		{Entity: "parameter", EntityID: 1, Field: "description", Locale: LocaleFR, Value: "Desc FR"},
+	}}
+}
+
+func TestEmptyValues_WriteByDefault(t *testing.T) {
+	repo := seededRepo()
+	trans := NewTranslator[Parameter](repo)
+
+	err := trans.SaveTranslations(context.Background(), []Parameter{{ID: 1, locale: LocaleFR, Name: "Nouveau"}})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []Translation{
+		{Entity: "parameter", EntityID: 1, Field: "name", Locale: LocaleFR, Value: "Nouveau"},
+		{Entity: "parameter", EntityID: 1, Field: "description", Locale: LocaleFR, Value: ""},
+	}, repo.saved)
+}
+
+func TestEmptyValues_Skip(t *testing.T) {
+	repo := seededRepo()
+	trans := NewTranslatorWithOptions(TranslatorOptions[Parameter]{Repository: repo, EmptyValues: EmptyValueSkip})
+
+	err := trans.SaveTranslations(context.Background(), []Parameter{{ID: 1, locale: LocaleFR, Name: "Nouveau"}})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []Translation{
+		{Entity: "parameter", EntityID: 1, Field: "name", Locale: LocaleFR, Value: "Nouveau"},
+		{Entity: "parameter", EntityID: 1, Field: "description", Locale: LocaleFR, Value: "Desc FR"},
+	}, repo.saved)
+}
+
+func TestEmptyValues_Delete(t *testing.T) {
+	repo := seededRepo()
+	repo.saved = append(repo.saved, Translation{Entity: "parameter", EntityID: 1, Field: "description", Locale: LocaleEN, Value: "Desc EN"})
+	trans := NewTranslatorWithOptions(TranslatorOptions[Parameter]{Repository: repo, EmptyValues: EmptyValueDelete})
+
+	err := trans.SaveTranslations(context.Background(), []Parameter{{ID: 1, locale: LocaleFR, Name: "Nouveau"}})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []Translation{
+		{Entity: "parameter", EntityID: 1, Field: "description", Locale: LocaleEN, Value: "Desc EN"},
+		{Entity: "parameter", EntityID: 1, Field: "name", Locale: LocaleFR, Value: "Nouveau"},
+	}, repo.saved, "only the FR description is deleted")
+}
+
+func TestSaveOptions_FieldMask(t *testing.T) {
+	repo := seededRepo()
+	trans := NewTranslatorWithOptions(TranslatorOptions[Parameter]{Repository: repo, EmptyValues: EmptyValueDelete})
+
+	// Description is empty but outside the mask, so it is neither written nor deleted.
+	err := trans.SaveTranslationsWithOptions(context.Background(),
+		[]Parameter{{ID: 1, locale: LocaleFR, Name: "Nouveau"}},
+		SaveOptions{Fields: []string{"name"}},
+	)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []Translation{
+		{Entity: "parameter", EntityID: 1, Field: "name", Locale: LocaleFR, Value: "Nouveau"},
+		{Entity: "parameter", EntityID: 1, Field: "description", Locale: LocaleFR, Value: "Desc FR"},
+	}, repo.saved)
+}
+
+func TestSaveOptions_UnknownField(t *testing.T) {
+	repo := seededRepo()
+	trans := NewTranslator[Parameter](repo)
+
+	err := trans.SaveTranslationsWithOptions(context.Background(),
+		[]Parameter{{ID: 1, locale: LocaleFR, Name: "Nouveau"}},
+		SaveOptions{Fields: []string{"Name"}},
+	)
+	require.ErrorIs(t, err, ErrUnknownField)
+	require.Len(t, repo.saved, 2, "nothing is written when the mask is invalid")
+}
+
+func missingRepo() *mockRepo {
+	return &mockRepo{translations: []Translation{
+		{Entity: "parameter", EntityID: 1, Field: "name", Locale: LocaleFR, Value: "Nom"},
+	}}
+}
+
+func TestMissingFields_KeepByDefault(t *testing.T) {
+	trans := NewTranslator[Parameter](missingRepo())
+
+	parms, err := trans.LoadTranslations(context.Background(), []Parameter{
+		{ID: 1, locale: LocaleFR, Description: "Source text"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Nom", parms[0].Name)
+	require.Equal(t, "Source text", parms[0].Description)
+}
+
+func TestMissingFields_Zero(t *testing.T) {
+	trans := NewTranslatorWithOptions(TranslatorOptions[Parameter]{Repository: missingRepo(), MissingFields: MissingFieldZero})
+
+	parms, err := trans.LoadTranslations(context.Background(), []Parameter{
+		{ID: 1, locale: LocaleFR, Name: "stale", Description: "stale"},
+		{ID: 2, locale: LocaleFR, Name: "stale", Description: "stale"}, // no rows at all
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Nom", parms[0].Name)
+	require.Empty(t, parms[0].Description)
+	require.Empty(t, parms[1].Name)
+	require.Empty(t, parms[1].Description)
+}
+
+func TestMissingFields_ZeroOptionalFields(t *testing.T) {
+	trans := NewTranslatorWithOptions(TranslatorOptions[Listing]{Repository: &mockRepo{}, MissingFields: MissingFieldZero})
+
+	listings, err := trans.LoadTranslations(context.Background(), []Listing{
+		{ID: 1, locale: LocaleFR, Subtitle: strPtr("stale"), Bullets: []string{"stale"}},
+	})
+	require.NoError(t, err)
+	require.Nil(t, listings[0].Subtitle)
+	require.Nil(t, listings[0].Bullets)
+}
+
+func TestMissingFields_Placeholder(t *testing.T) {
+	trans := NewTranslatorWithOptions(TranslatorOptions[Parameter]{Repository: missingRepo(), MissingFields: MissingFieldPlaceholder})
+
+	parms, err := trans.LoadTranslations(context.Background(), []Parameter{{ID: 1, locale: LocaleFR}})
+	require.NoError(t, err)
+	require.Equal(t, "Nom", parms[0].Name)
+	require.Equal(t, "[missing:description]", parms[0].Description)
+
+	custom := NewTranslatorWithOptions(TranslatorOptions[Article]{
+		Repository:               &mockRepo{},
+		MissingFields:            MissingFieldPlaceholder,
+		MissingPlaceholderFormat: "<<%s>>",
+	})
+	articles, err := custom.LoadTranslations(context.Background(), []Article{{ID: 1, locale: LocaleFR}})
+	require.NoError(t, err)
+	require.Equal(t, "<<title>>", articles[0].Title, "setter path applies the placeholder too")
+	require.Equal(t, "<<body>>", articles[0].Body)
+}
+
+func TestLoadTranslationsWithReport(t *testing.T) {
+	trans := NewTranslator[*Parameter](missingRepo())
+
+	parms, report, err := trans.LoadTranslationsWithReport(context.Background(), []*Parameter{
+		{ID: 1, locale: LocaleFR},
+		nil,
+		{ID: 2, locale: LocaleFR},
+	})
+	require.NoError(t, err)
+	require.Len(t, parms, 3)
+	require.Equal(t, []EntityReport{
+		{
+			EntityID:   1,
+			Locale:     LocaleFR,
+			Fields:     map[string]FieldProvenance{"name": {Locale: LocaleFR, Source: SourceRepository}},
+			Unresolved: []string{"description"},
+		},
+		{},
+		{EntityID: 2, Locale: LocaleFR, Unresolved: []string{"description", "name"}},
+	}, report.Entities)
+	require.Len(t, report.Unresolved(), 2)
+}
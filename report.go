@@ -0,0 +1,83 @@
+package gotrans
+
+import "sort"
+
+// LoadReport describes the outcome of LoadTranslationsWithReport.
+type LoadReport struct {
+	// Entities is index-aligned with the entities passed in.
+	// Entries for nil pointer entities are left zero.
+	Entities []EntityReport
+}
+
+// EntityReport describes one loaded entity.
+type EntityReport struct {
+	EntityID int
+	Locale   Locale
+	// Fields holds the provenance of every resolved field, keyed by DB field ID.
+	Fields map[string]FieldProvenance
+	// Unresolved lists the DB field IDs that had no stored translation, in
+	// stable order. The missing-field policy has already been applied to them.
+	Unresolved []string
+}
+
+// FallbackFields returns the DB field IDs served from a fallback locale, in
+// stable order — e.g. to show a "partially translated" banner.
+func (e EntityReport) FallbackFields() []string {
+	var fields []string
+	for field, p := range e.Fields {
+		if p.Fallback {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// FieldSource tells whether a value was served from cache or read from the repository.
+type FieldSource uint8
+
+const (
+	// SourceRepository means the row was read from the underlying repository,
+	// typically the database.
+	SourceRepository FieldSource = iota + 1
+	// SourceCache means the row was served by a cached repository without
+	// reaching the underlying store.
+	SourceCache
+)
+
+// String returns "repository" or "cache".
+func (s FieldSource) String() string {
+	switch s {
+	case SourceRepository:
+		return "repository"
+	case SourceCache:
+		return "cache"
+	}
+	return "unknown"
+}
+
+// FieldProvenance describes where a loaded field value came from.
+type FieldProvenance struct {
+	// Locale is the locale of the row the value was taken from.
+	Locale Locale
+	// Fallback is true when Locale differs from the entity's locale.
+	Fallback bool
+	// Source tells whether the row came from cache or the repository.
+	Source FieldSource
+	// TranslationID is the Translation.ID of the row.
+	TranslationID int
+	// Stale is true when the source-locale value changed after the row was
+	// saved. Only set with TranslatorOptions.ReportStale.
+	Stale bool
+}
+
+// Unresolved returns the entries that have at least one unresolved field.
+func (r LoadReport) Unresolved() []EntityReport {
+	var out []EntityReport
+	for _, e := range r.Entities {
+		if len(e.Unresolved) > 0 {
+			out = append(out, e)
+		}
+	}
+	return out
+}
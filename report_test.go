@@ -0,0 +1,95 @@
+package gotrans
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func provenanceRepo() *countingRepo {
+	return &countingRepo{mockRepo: mockRepo{translations: []Translation{
+		{ID: 10, Entity: "parameter", EntityID: 1, Field: "name", Locale: LocaleFR, Value: "Nom"},
+		{ID: 11, Entity: "parameter", EntityID: 1, Field: "description", Locale: LocaleEN, Value: "Desc EN"},
+		{ID: 12, Entity: "parameter", EntityID: 1, Field: "name", Locale: LocaleEN, Value: "Name EN"},
+		{ID: 13, Entity: "parameter", EntityID: 2, Field: "name", Locale: LocaleFR, Value: "Nom 2"},
+		{ID: 14, Entity: "parameter", EntityID: 2, Field: "description", Locale: LocaleFR, Value: "Desc 2"},
+	}}}
+}
+
+func TestFallbackLocales(t *testing.T) {
+	base := provenanceRepo()
+	trans := NewTranslatorWithOptions(TranslatorOptions[Parameter]{
+		Repository:      base,
+		FallbackLocales: []Locale{LocaleEN},
+	})
+
+	parms, err := trans.LoadTranslations(context.Background(), []Parameter{
+		{ID: 1, locale: LocaleFR},
+		{ID: 2, locale: LocaleFR},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Nom", parms[0].Name, "own locale wins over the fallback")
+	require.Equal(t, "Desc EN", parms[0].Description)
+	require.Equal(t, "Desc 2", parms[1].Description)
+	// One FR query plus one EN query, and the EN query only for entity 1.
+	require.Equal(t, 2, base.getCalls)
+}
+
+func TestFallbackLocales_RegionToBase(t *testing.T) {
+	base := &countingRepo{mockRepo: mockRepo{translations: []Translation{
+		{ID: 20, Entity: "parameter", EntityID: 1, Field: "name", Locale: LocaleENGB, Value: "Colour"},
+		{ID: 21, Entity: "parameter", EntityID: 1, Field: "description", Locale: LocaleEN, Value: "Desc EN"},
+		{ID: 22, Entity: "parameter", EntityID: 1, Field: "description", Locale: LocaleFR, Value: "Desc FR"},
+		{ID: 23, Entity: "parameter", EntityID: 2, Field: "name", Locale: LocaleFR, Value: "Nom"},
+		{ID: 24, Entity: "parameter", EntityID: 2, Field: "description", Locale: LocaleFR, Value: "Desc"},
+	}}}
+	trans := NewTranslatorWithOptions(TranslatorOptions[Parameter]{
+		Repository:      base,
+		FallbackLocales: []Locale{LocaleFR},
+	})
+
+	parms, report, err := trans.LoadTranslationsWithReport(context.Background(), []Parameter{
+		{ID: 1, locale: LocaleENGB},
+		{ID: 2, locale: LocaleFR},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Colour", parms[0].Name)
+	require.Equal(t, "Desc EN", parms[0].Description, "the base language comes before configured fallbacks")
+	require.Equal(t, FieldProvenance{Locale: LocaleEN, Fallback: true, Source: SourceRepository, TranslationID: 21},
+		report.Entities[0].Fields["description"])
+	require.Equal(t, "Nom", parms[1].Name)
+	// en-GB and fr, then en for entity 1 only; fr is never needed again.
+	require.Equal(t, 3, base.getCalls)
+}
+
+func TestLoadTranslationsWithReport_Provenance(t *testing.T) {
+	base := provenanceRepo()
+	cached := NewCachedRepositoryInMemory(base, CacheOptions{TTL: time.Minute})
+	trans := NewTranslatorWithOptions(TranslatorOptions[Parameter]{
+		Repository:      cached,
+		FallbackLocales: []Locale{LocaleEN},
+	})
+	ctx := context.Background()
+
+	// Warm only the FR entry of entity 1.
+	_, err := cached.GetTranslations(ctx, LocaleFR, "parameter", []int{1})
+	require.NoError(t, err)
+
+	_, report, err := trans.LoadTranslationsWithReport(ctx, []Parameter{{ID: 1, locale: LocaleFR}})
+	require.NoError(t, err)
+	require.Equal(t, map[string]FieldProvenance{
+		"name":        {Locale: LocaleFR, Source: SourceCache, TranslationID: 10},
+		"description": {Locale: LocaleEN, Fallback: true, Source: SourceRepository, TranslationID: 11},
+	}, report.Entities[0].Fields)
+	require.Empty(t, report.Entities[0].Unresolved)
+	require.Equal(t, []string{"description"}, report.Entities[0].FallbackFields())
+
+	// Second load: both rows now come from cache.
+	_, report, err = trans.LoadTranslationsWithReport(ctx, []Parameter{{ID: 1, locale: LocaleFR}})
+	require.NoError(t, err)
+	for field, p := range report.Entities[0].Fields {
+		require.Equal(t, SourceCache, p.Source, field)
+	}
+}
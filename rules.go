@@ -0,0 +1,247 @@
+package gotrans
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Rule checks a single translation value. Check returns "" when the value
+// passes and a human-readable reason otherwise.
+type Rule interface {
+	Name() string
+	Check(tr Translation) string
+}
+
+// RuleValidator applies per-field rules to every saved value and reports all
+// failures as one *ValidationError.
+//
+//	gotrans.RuleValidator{
+//		Fields: []string{"title"},
+//		Rules: []gotrans.Rule{
+//			gotrans.MaxLength{Max: 60},
+//			gotrans.Required{Locales: []gotrans.Locale{gotrans.LocaleEN}},
+//		},
+//	}
+type RuleValidator struct {
+	// Fields limits the rules to these DB field IDs. Empty means every field.
+	// Plural variants ("title#one") are matched by their base ID.
+	Fields []string
+	Rules  []Rule
+}
+
+// ValidateTranslations implements TranslationValidator.
+func (v RuleValidator) ValidateTranslations(_ context.Context, _ TranslationRepository, trs []Translation) error {
+	var violations []Violation
+	for _, tr := range trs {
+		if !matchField(v.Fields, tr.Field) {
+			continue
+		}
+		for _, r := range v.Rules {
+			if msg := r.Check(tr); msg != "" {
+				violations = append(violations, newViolation(tr, r.Name(), msg, nil))
+			}
+		}
+	}
+	return violationsError(violations)
+}
+
+// MaxLength limits the length of a value, counted in runes or, with Width,
+// in terminal display columns (East Asian wide characters count as two,
+// combining marks as zero).
+type MaxLength struct {
+	Max   int
+	Width bool
+}
+
+func (MaxLength) Name() string { return "max_length" }
+
+func (r MaxLength) Check(tr Translation) string {
+	if r.Width {
+		if n := DisplayWidth(tr.Value); n > r.Max {
+			return fmt.Sprintf("display width %d exceeds %d", n, r.Max)
+		}
+		return ""
+	}
+	if n := utf8.RuneCountInString(tr.Value); n > r.Max {
+		return fmt.Sprintf("length %d exceeds %d", n, r.Max)
+	}
+	return ""
+}
+
+// Required rejects empty (or whitespace-only) values in the given locales.
+// With no locales it applies to every locale. Only rows in the save call are
+// checked, so combine it with a field mask or EmptyValueWrite/Delete: an
+// entity whose field is absent from the call is not reported.
+type Required struct {
+	Locales []Locale
+}
+
+func (Required) Name() string { return "required" }
+
+func (r Required) Check(tr Translation) string {
+	if len(r.Locales) > 0 && !containsLocale(r.Locales, tr.Locale) {
+		return ""
+	}
+	if strings.TrimSpace(tr.Value) == "" {
+		return "value is required in " + tr.Locale.String()
+	}
+	return ""
+}
+
+// AllowedHTML rejects HTML tags other than Tags (case-insensitive). With no
+// tags, any markup is rejected.
+type AllowedHTML struct {
+	Tags []string
+}
+
+var htmlTagRe = regexp.MustCompile(`</?([a-zA-Z][a-zA-Z0-9-]*)\b[^<>]*>`)
+
+func (AllowedHTML) Name() string { return "allowed_html" }
+
+func (r AllowedHTML) Check(tr Translation) string {
+	var bad []string
+	seen := make(map[string]bool)
+	for _, m := range htmlTagRe.FindAllStringSubmatch(tr.Value, -1) {
+		tag := strings.ToLower(m[1])
+		if seen[tag] || r.allowed(tag) {
+			continue
+		}
+		seen[tag] = true
+		bad = append(bad, "<"+tag+">")
+	}
+	if len(bad) > 0 {
+		return "tag not allowed: " + strings.Join(bad, ", ")
+	}
+	return ""
+}
+
+func (r AllowedHTML) allowed(tag string) bool {
+	for _, t := range r.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// ForbiddenChars rejects values containing any rune of Chars. With
+// Control set, Unicode control characters other than \t, \n and \r are
+// rejected as well.
+type ForbiddenChars struct {
+	Chars   string
+	Control bool
+}
+
+func (ForbiddenChars) Name() string { return "forbidden_chars" }
+
+func (r ForbiddenChars) Check(tr Translation) string {
+	for _, c := range tr.Value {
+		if strings.ContainsRune(r.Chars, c) {
+			return fmt.Sprintf("forbidden character %q", c)
+		}
+		if r.Control && unicode.IsControl(c) && c != '\t' && c != '\n' && c != '\r' {
+			return fmt.Sprintf("control character %U", c)
+		}
+	}
+	return ""
+}
+
+// ValidMarkdown rejects values with broken Markdown structure: an unclosed
+// code fence, an unmatched inline code span, unbalanced "**" emphasis or an
+// unterminated link. It is a structural check, not a full CommonMark parser.
+type ValidMarkdown struct{}
+
+func (ValidMarkdown) Name() string { return "markdown" }
+
+func (ValidMarkdown) Check(tr Translation) string {
+	inFence := false
+	var text strings.Builder
+	for _, line := range strings.Split(tr.Value, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if !inFence {
+			text.WriteString(line)
+			text.WriteByte('\n')
+		}
+	}
+	if inFence {
+		return "unclosed code fence"
+	}
+	// Inline code first, its content is literal.
+	parts := strings.Split(text.String(), "`")
+	if len(parts)%2 == 0 {
+		return "unmatched ` in inline code"
+	}
+	var prose strings.Builder
+	for i := 0; i < len(parts); i += 2 {
+		prose.WriteString(parts[i])
+	}
+	s := strings.ReplaceAll(prose.String(), `\*`, "")
+	if strings.Count(s, "**")%2 != 0 {
+		return "unbalanced ** emphasis"
+	}
+	for rest := s; ; {
+		i := strings.Index(rest, "](")
+		if i < 0 {
+			break
+		}
+		rest = rest[i+2:]
+		end := strings.IndexAny(rest, ")\n")
+		if end < 0 || rest[end] != ')' {
+			return "unterminated link"
+		}
+		rest = rest[end+1:]
+	}
+	return ""
+}
+
+// DisplayWidth returns the number of terminal columns s occupies: East Asian
+// wide and fullwidth characters count as two, combining marks and format
+// characters as zero.
+func DisplayWidth(s string) int {
+	n := 0
+	for _, r := range s {
+		switch {
+		case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		case isWide(r):
+			n += 2
+		default:
+			n++
+		}
+	}
+	return n
+}
+
+// isWide reports East Asian Wide (W) and Fullwidth (F) runes, per the main
+// blocks of Unicode UAX #11.
+func isWide(r rune) bool {
+	return r >= 0x1100 && r <= 0x115F || // Hangul Jamo
+		r >= 0x2E80 && r <= 0x303E || // CJK radicals, punctuation
+		r >= 0x3041 && r <= 0x33FF || // Hiragana, Katakana, CJK compatibility
+		r >= 0x3400 && r <= 0x4DBF || // CJK extension A
+		r >= 0x4E00 && r <= 0x9FFF || // CJK unified ideographs
+		r >= 0xA000 && r <= 0xA4CF || // Yi
+		r >= 0xAC00 && r <= 0xD7A3 || // Hangul syllables
+		r >= 0xF900 && r <= 0xFAFF || // CJK compatibility ideographs
+		r >= 0xFE30 && r <= 0xFE4F || // CJK compatibility forms
+		r >= 0xFF00 && r <= 0xFF60 || // Fullwidth forms
+		r >= 0xFFE0 && r <= 0xFFE6 ||
+		r >= 0x1F300 && r <= 0x1F64F || // Emoji
+		r >= 0x1F900 && r <= 0x1F9FF ||
+		r >= 0x20000 && r <= 0x3FFFD // CJK extensions B and later
+}
+
+func containsLocale(locales []Locale, l Locale) bool {
+	for _, x := range locales {
+		if x == l {
+			return true
+		}
+	}
+	return false
+}
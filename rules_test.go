@@ -0,0 +1,100 @@
+package gotrans
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRules(t *testing.T) {
+	tr := func(v string) Translation { return Translation{Locale: LocaleEN, Value: v} }
+	cases := []struct {
+		rule  Rule
+		value string
+		want  string
+	}{
+		{MaxLength{Max: 3}, "héé", ""},
+		{MaxLength{Max: 3}, "héllo", "length 5 exceeds 3"},
+		{MaxLength{Max: 4, Width: true}, "日本", ""},
+		{MaxLength{Max: 4, Width: true}, "日本語", "display width 6 exceeds 4"},
+		{Required{}, " ", "value is required in en"},
+		{Required{Locales: []Locale{LocaleFR}}, "", ""},
+		{AllowedHTML{Tags: []string{"b", "a"}}, `<b>x</b> <A href="#">y</A>`, ""},
+		{AllowedHTML{Tags: []string{"b"}}, `<b>x</b><script>1</script><i>y</i>`, "tag not allowed: <script>, <i>"},
+		{AllowedHTML{}, "1 < 2 > 0", ""},
+		{ForbiddenChars{Chars: "<>"}, "a > b", `forbidden character '>'`},
+		{ForbiddenChars{Control: true}, "a\tb\n", ""},
+		{ForbiddenChars{Control: true}, "a\x00b", "control character U+0000"},
+		{ValidMarkdown{}, "**bold** and `co**de` [link](https://x.y)\n```\n**\n```", ""},
+		{ValidMarkdown{}, "```go\nx", "unclosed code fence"},
+		{ValidMarkdown{}, "a `b", "unmatched ` in inline code"},
+		{ValidMarkdown{}, "**bold", "unbalanced ** emphasis"},
+		{ValidMarkdown{}, "[link](https://x.y\nmore", "unterminated link"},
+	}
+	for _, c := range cases {
+		require.Equal(t, c.want, c.rule.Check(tr(c.value)), "%s %q", c.rule.Name(), c.value)
+	}
+}
+
+func TestDisplayWidth(t *testing.T) {
+	require.Equal(t, 5, DisplayWidth("hello"))
+	require.Equal(t, 4, DisplayWidth("한국"))
+	require.Equal(t, 1, DisplayWidth("e\u0301"), "combining accent has no width")
+	require.Equal(t, 2, DisplayWidth("Ａ"))
+}
+
+func TestValidators_AggregateViolations(t *testing.T) {
+	repo := &mockRepo{translations: []Translation{
+		{Entity: "parameter", EntityID: 1, Field: "name", Locale: LocaleEN, Value: "{count} items"},
+	}}
+	trans := NewTranslatorWithOptions(TranslatorOptions[Parameter]{
+		Repository: repo,
+		Validators: []TranslationValidator{
+			RuleValidator{Fields: []string{"name"}, Rules: []Rule{MaxLength{Max: 10}}},
+			RuleValidator{Fields: []string{"description"}, Rules: []Rule{Required{Locales: []Locale{LocaleFR}}}},
+			MessageFormatValidator{},
+			PlaceholderValidator{SourceLocale: LocaleEN},
+		},
+	})
+
+	err := trans.SaveTranslations(context.Background(), []Parameter{
+		{ID: 1, locale: LocaleFR, Name: "{nombre} articles"},
+		{ID: 2, locale: LocaleFR, Name: "ok", Description: "{broken"},
+	})
+	var ve *ValidationError
+	require.ErrorAs(t, err, &ve)
+	type key struct {
+		id   int
+		rule string
+	}
+	var got []key
+	for _, v := range ve.Violations {
+		got = append(got, key{v.EntityID, v.Rule})
+	}
+	require.ElementsMatch(t, []key{
+		{1, "max_length"},
+		{1, "required"},
+		{2, "message_format"},
+		{1, "placeholders"},
+	}, got)
+
+	var se *MessageSyntaxError
+	require.ErrorAs(t, err, &se, "causes stay reachable through the aggregate")
+	require.Empty(t, repo.saved)
+}
+
+func TestValidators_OtherErrorsAbort(t *testing.T) {
+	dbDown := errors.New("db down")
+	repo := &mockRepo{getErr: dbDown}
+	trans := NewTranslatorWithOptions(TranslatorOptions[Parameter]{
+		Repository: repo,
+		Validators: []TranslationValidator{PlaceholderValidator{SourceLocale: LocaleEN}},
+	})
+
+	err := trans.SaveTranslations(context.Background(), []Parameter{{ID: 1, locale: LocaleFR, Name: "x"}})
+	require.ErrorIs(t, err, dbDown)
+	var ve *ValidationError
+	require.False(t, errors.As(err, &ve))
+}
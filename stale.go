@@ -0,0 +1,85 @@
+package gotrans
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+)
+
+// fieldKey identifies a field of an entity instance, in no particular locale.
+type fieldKey struct {
+	id    int
+	field string
+}
+
+// sourceHash returns the SourceHash of a source value stored as rows: one
+// row for a plain field, one per category for a PluralText field. rows are
+// sorted in place.
+func sourceHash(rows []Translation) string {
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Field < rows[j].Field })
+	h := sha256.New()
+	for _, tr := range rows {
+		h.Write([]byte(tr.Field))
+		h.Write([]byte{0})
+		h.Write([]byte(tr.Value))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// currentSourceHashes returns the source hash per entity and base field of
+// ids. Source rows in pending, about to be saved, replace the stored ones of
+// their field.
+func (t *translator[T]) currentSourceHashes(ctx context.Context, ids []int, pending []Translation) (map[fieldKey]string, error) {
+	rows := make(map[fieldKey][]Translation)
+	if len(ids) > 0 {
+		sort.Ints(ids)
+		stored, err := t.repo.GetTranslations(ctx, t.sourceLocale, t.entityName, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, tr := range stored {
+			k := fieldKey{tr.EntityID, t.baseField(tr.Field)}
+			rows[k] = append(rows[k], tr)
+		}
+	}
+	replaced := make(map[fieldKey]struct{})
+	for _, tr := range pending {
+		k := fieldKey{tr.EntityID, t.baseField(tr.Field)}
+		if _, ok := replaced[k]; !ok {
+			replaced[k] = struct{}{}
+			rows[k] = nil
+		}
+		rows[k] = append(rows[k], tr)
+	}
+
+	hashes := make(map[fieldKey]string, len(rows))
+	for k, trs := range rows {
+		hashes[k] = sourceHash(trs)
+	}
+	return hashes, nil
+}
+
+// stampSourceHashes sets SourceHash on the translations about to be saved:
+// source rows get the hash of their own field, other locales the hash of
+// the source value they are saved against, or "" when there is none.
+func (t *translator[T]) stampSourceHashes(ctx context.Context, byLocale map[Locale][]Translation) error {
+	var ids []int
+	for locale, trs := range byLocale {
+		if locale != t.sourceLocale {
+			ids = append(ids, entityIDsOf(trs)...)
+		}
+	}
+	pending := append([]Translation(nil), byLocale[t.sourceLocale]...)
+	hashes, err := t.currentSourceHashes(ctx, uniqueInts(ids), pending)
+	if err != nil {
+		return err
+	}
+	for _, trs := range byLocale {
+		for i := range trs {
+			trs[i].SourceHash = hashes[fieldKey{trs[i].EntityID, t.baseField(trs[i].Field)}]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package gotrans
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslator_StaleTranslations(t *testing.T) {
+	repo := &savedRepo{}
+	trans := NewTranslatorWithOptions(TranslatorOptions[Parameter]{
+		Repository:   repo,
+		SourceLocale: LocaleEN,
+		ReportStale:  true,
+	})
+	ctx := context.Background()
+
+	require.NoError(t, trans.SaveTranslations(ctx, []Parameter{
+		{ID: 1, locale: LocaleEN, Name: "Size", Description: "Box size"},
+		{ID: 1, locale: LocaleDE, Name: "Größe", Description: "Kistengröße"},
+	}))
+	stale := func() map[string]bool {
+		_, report, err := trans.LoadTranslationsWithReport(ctx, []Parameter{{ID: 1, locale: LocaleDE}})
+		require.NoError(t, err)
+		out := make(map[string]bool)
+		for field, p := range report.Entities[0].Fields {
+			out[field] = p.Stale
+		}
+		return out
+	}
+	require.Equal(t, map[string]bool{"name": false, "description": false}, stale())
+
+	require.NoError(t, trans.SaveTranslations(ctx, []Parameter{{ID: 1, locale: LocaleEN, Name: "Dimensions", Description: "Box size"}}))
+	require.Equal(t, map[string]bool{"name": true, "description": false}, stale(), "only the changed source field makes its translations stale")
+
+	require.NoError(t, trans.SaveTranslationsWithOptions(ctx, []Parameter{{ID: 1, locale: LocaleDE, Name: "Abmessungen"}}, SaveOptions{Fields: []string{"name"}}))
+	require.Equal(t, map[string]bool{"name": false, "description": false}, stale())
+
+	_, report, err := NewTranslator[Parameter](repo).LoadTranslationsWithReport(ctx, []Parameter{{ID: 1, locale: LocaleDE}})
+	require.NoError(t, err)
+	require.False(t, report.Entities[0].Fields["name"].Stale, "staleness is only reported when asked for")
+}
@@ -1,5 +1,7 @@
 package gotrans
 
+import "time"
+
 // Translation represents a translated field value for a specific entity and locale.
 // ID is typically auto-incremented by the database and may be omitted for insert operations.
 // Entity is the type name (e.g., "product"), EntityID identifies the specific entity instance.
@@ -19,4 +21,32 @@ type Translation struct {
 	Locale Locale
 	// Value contains the translated text.
 	Value string
+	// Status is the editorial workflow state. Repositories without workflow
+	// support leave it zero, StatusPublished.
+	Status TranslationStatus
+	// SourceHash identifies the source-locale value the translation was
+	// based on, see TranslatorOptions.SourceLocale. Empty when unknown.
+	SourceHash string
+	// Version is the stored version of the row, incremented on every write
+	// by repositories that track versions and 0 otherwise. See
+	// WithVersionCheck for its meaning in MassCreateOrUpdate.
+	Version int64
+	// CreatedAt and UpdatedAt are maintained by repositories that track
+	// timestamps and zero otherwise.
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TranslationKey identifies one stored translation: a field of an entity
+// instance in a locale.
+type TranslationKey struct {
+	Entity   string `json:"entity"`
+	EntityID int    `json:"entity_id"`
+	Field    string `json:"field"`
+	Locale   Locale `json:"locale"`
+}
+
+// Key returns the TranslationKey of tr.
+func (tr Translation) Key() TranslationKey {
+	return TranslationKey{Entity: tr.Entity, EntityID: tr.EntityID, Field: tr.Field, Locale: tr.Locale}
 }
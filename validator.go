@@ -0,0 +1,117 @@
+package gotrans
+
+import (
+	"context"
+	"fmt"
+)
+
+// TranslationValidator checks translations before SaveTranslations writes
+// them. It receives every row of one save call within the field mask, empty
+// values included, and the translator's repository for validators that
+// compare against stored values.
+//
+// Report rule failures as a *ValidationError; the translator merges those of
+// all validators into one. Any other error aborts the save as is.
+type TranslationValidator interface {
+	ValidateTranslations(ctx context.Context, repo TranslationRepository, trs []Translation) error
+}
+
+// MessageFormatValidator rejects values that are not valid ICU MessageFormat
+// patterns, so syntax errors surface at save time instead of at render time.
+type MessageFormatValidator struct {
+	// Fields limits validation to these DB field IDs. Empty means every field.
+	// Plural variants ("title#one") are matched by their base ID.
+	Fields []string
+}
+
+// ValidateTranslations implements TranslationValidator. Each violation
+// carries the *MessageSyntaxError in Err.
+func (v MessageFormatValidator) ValidateTranslations(_ context.Context, _ TranslationRepository, trs []Translation) error {
+	var violations []Violation
+	for _, tr := range trs {
+		if !matchField(v.Fields, tr.Field) {
+			continue
+		}
+		if _, err := ParseMessage(tr.Value); err != nil {
+			violations = append(violations, newViolation(tr, "message_format", err.Error(), err))
+		}
+	}
+	return violationsError(violations)
+}
+
+// matchField reports whether field, or the base ID of a plural variant, is in
+// fields. An empty list matches everything.
+func matchField(fields []string, field string) bool {
+	if len(fields) == 0 {
+		return true
+	}
+	base, _, _ := splitPluralFieldID(field)
+	for _, f := range fields {
+		if f == field || f == base {
+			return true
+		}
+	}
+	return false
+}
+
+// Violation is one translation that failed validation.
+type Violation struct {
+	Entity   string
+	EntityID int
+	Field    string
+	Locale   Locale
+	Rule     string // validator-specific rule name, e.g. "placeholders"
+	Message  string
+	Err      error // underlying cause, if any, e.g. a *MessageSyntaxError
+}
+
+func newViolation(tr Translation, rule, message string, err error) Violation {
+	return Violation{
+		Entity:   tr.Entity,
+		EntityID: tr.EntityID,
+		Field:    tr.Field,
+		Locale:   tr.Locale,
+		Rule:     rule,
+		Message:  message,
+		Err:      err,
+	}
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s %d field %q (%s): %s: %s", v.Entity, v.EntityID, v.Field, v.Locale, v.Rule, v.Message)
+}
+
+// ValidationError lists every violation found in one save call. Inspect it
+// with errors.As.
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	switch len(e.Violations) {
+	case 0:
+		return "gotrans: validation failed"
+	case 1:
+		return "gotrans: validation failed: " + e.Violations[0].String()
+	}
+	return fmt.Sprintf("gotrans: validation failed: %s (and %d more)", e.Violations[0], len(e.Violations)-1)
+}
+
+// Unwrap exposes the causes of the violations to errors.Is and errors.As.
+func (e *ValidationError) Unwrap() []error {
+	var errs []error
+	for _, v := range e.Violations {
+		if v.Err != nil {
+			errs = append(errs, v.Err)
+		}
+	}
+	return errs
+}
+
+// violationsError returns a *ValidationError for violations, or nil if there are none.
+func violationsError(violations []Violation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	return &ValidationError{Violations: violations}
+}
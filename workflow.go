@@ -0,0 +1,81 @@
+package gotrans
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrInvalidTransition is returned when a translation's status can't
+	// move to the requested one.
+	ErrInvalidTransition = errors.New("invalid status transition")
+	// ErrTranslationNotFound is returned when an operation needs a stored
+	// translation that doesn't exist.
+	ErrTranslationNotFound = errors.New("translation not found")
+)
+
+// TranslationStatus is the editorial workflow state of a stored translation.
+// The zero value is StatusPublished, so translations saved without a
+// workflow, and rows stored before it existed, are live.
+type TranslationStatus uint8
+
+const (
+	StatusPublished TranslationStatus = iota
+	StatusDraft
+	StatusInReview
+	StatusApproved
+)
+
+func (s TranslationStatus) String() string {
+	switch s {
+	case StatusPublished:
+		return "published"
+	case StatusDraft:
+		return "draft"
+	case StatusInReview:
+		return "in_review"
+	case StatusApproved:
+		return "approved"
+	}
+	return "unknown"
+}
+
+// statusTransitions lists the statuses each status may move to. Every
+// status may also "move" to itself.
+var statusTransitions = map[TranslationStatus][]TranslationStatus{
+	StatusDraft:     {StatusInReview},
+	StatusInReview:  {StatusDraft, StatusApproved},
+	StatusApproved:  {StatusDraft, StatusPublished},
+	StatusPublished: {StatusDraft},
+}
+
+// CanTransitionTo reports whether a translation in status s may move to to:
+// draft → in review → approved → published, back to draft from any of the
+// later states, and in review back to draft when rejected.
+func (s TranslationStatus) CanTransitionTo(to TranslationStatus) bool {
+	if s == to {
+		_, known := statusTransitions[s]
+		return known
+	}
+	for _, next := range statusTransitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+type previewContextKey struct{}
+
+// WithPreview returns a copy of ctx in which LoadTranslations serves
+// translations in every status instead of only published ones, so editors
+// can see drafts in place.
+func WithPreview(ctx context.Context) context.Context {
+	return context.WithValue(ctx, previewContextKey{}, true)
+}
+
+// PreviewFromContext reports whether ctx was made by WithPreview.
+func PreviewFromContext(ctx context.Context) bool {
+	preview, _ := ctx.Value(previewContextKey{}).(bool)
+	return preview
+}
@@ -0,0 +1,114 @@
+package gotrans
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslationStatus_CanTransitionTo(t *testing.T) {
+	require.True(t, StatusDraft.CanTransitionTo(StatusInReview))
+	require.True(t, StatusInReview.CanTransitionTo(StatusDraft))
+	require.True(t, StatusApproved.CanTransitionTo(StatusPublished))
+	require.True(t, StatusPublished.CanTransitionTo(StatusDraft))
+	require.True(t, StatusApproved.CanTransitionTo(StatusApproved))
+	require.False(t, StatusDraft.CanTransitionTo(StatusPublished))
+	require.False(t, StatusInReview.CanTransitionTo(StatusPublished))
+	require.False(t, TranslationStatus(9).CanTransitionTo(TranslationStatus(9)))
+}
+
+func TestTranslator_Workflow(t *testing.T) {
+	repo := &savedRepo{}
+	trans := NewTranslatorWithOptions(TranslatorOptions[Parameter]{
+		Repository:      repo,
+		FallbackLocales: []Locale{LocaleEN},
+	})
+	ctx := context.Background()
+
+	require.NoError(t, trans.SaveTranslations(ctx, []Parameter{{ID: 1, locale: LocaleEN, Name: "Size", Description: "Box size"}}))
+	draft := SaveOptions{Status: StatusDraft}
+	require.NoError(t, trans.SaveTranslationsWithOptions(ctx, []Parameter{{ID: 1, locale: LocaleDE, Name: "Größe", Description: "Kistengröße"}}, draft))
+
+	load := func(ctx context.Context) Parameter {
+		got, err := trans.LoadTranslations(ctx, []Parameter{{ID: 1, locale: LocaleDE}})
+		require.NoError(t, err)
+		return got[0]
+	}
+	require.Equal(t, "Size", load(ctx).Name, "drafts are not served; the fallback is")
+	require.Equal(t, "Größe", load(WithPreview(ctx)).Name)
+
+	err := trans.TransitionTranslations(ctx, LocaleDE, []int{1}, nil, StatusPublished)
+	require.ErrorIs(t, err, ErrInvalidTransition)
+	require.Equal(t, KindConflict, KindOf(err))
+
+	for _, to := range []TranslationStatus{StatusInReview, StatusApproved} {
+		require.NoError(t, trans.TransitionTranslations(ctx, LocaleDE, []int{1}, nil, to))
+	}
+	require.NoError(t, trans.TransitionTranslations(ctx, LocaleDE, []int{1}, []string{"name"}, StatusPublished))
+	got := load(ctx)
+	require.Equal(t, "Größe", got.Name)
+	require.Equal(t, "Box size", got.Description, "only the published field is served")
+
+	err = trans.TransitionTranslations(ctx, LocaleFR, []int{1}, []string{"name"}, StatusInReview)
+	require.ErrorIs(t, err, ErrTranslationNotFound)
+	require.Equal(t, KindNotFound, KindOf(err))
+	require.ErrorIs(t, trans.TransitionTranslations(ctx, LocaleDE, []int{1}, []string{"title"}, StatusDraft), ErrUnknownField)
+}
+
+func TestTranslator_RevertKeepsStatus(t *testing.T) {
+	store := NewInMemoryHistoryStore()
+	trans := NewTranslator[Parameter](NewHistoryRepository(&savedRepo{}, store, HistoryOptions{}))
+	ctx := context.Background()
+	draft := SaveOptions{Status: StatusDraft}
+
+	require.NoError(t, trans.SaveTranslationsWithOptions(ctx, []Parameter{{ID: 1, locale: LocaleDE, Name: "Größe"}}, draft))
+	require.NoError(t, trans.SaveTranslationsWithOptions(ctx, []Parameter{{ID: 1, locale: LocaleDE, Name: "Format"}}, draft))
+	revs, err := store.Revisions(ctx, TranslationKey{Entity: "parameter", EntityID: 1, Field: "name", Locale: LocaleDE})
+	require.NoError(t, err)
+
+	require.NoError(t, trans.RevertTranslation(ctx, revs[1]))
+	got, err := trans.LoadTranslations(ctx, []Parameter{{ID: 1, locale: LocaleDE}})
+	require.NoError(t, err)
+	require.Empty(t, got[0].Name, "a reverted draft is not published")
+	got, err = trans.LoadTranslations(WithPreview(ctx), []Parameter{{ID: 1, locale: LocaleDE}})
+	require.NoError(t, err)
+	require.Equal(t, "Größe", got[0].Name)
+}
+
+// interleavedRepo runs between once, right after the first read, to stand in
+// for a save that lands while another call is in flight.
+type interleavedRepo struct {
+	*versionedRepo
+	between func()
+}
+
+func (r *interleavedRepo) GetTranslations(ctx context.Context, locale Locale, entity string, entityIDs []int) ([]Translation, error) {
+	trs, err := r.versionedRepo.GetTranslations(ctx, locale, entity, entityIDs)
+	if between := r.between; between != nil {
+		r.between = nil
+		between()
+	}
+	return trs, err
+}
+
+func TestTranslator_TransitionKeepsConcurrentSave(t *testing.T) {
+	base := &versionedRepo{}
+	repo := &interleavedRepo{versionedRepo: base}
+	trans := NewTranslator[*versionedParameter](repo)
+	ctx := context.Background()
+	draft := SaveOptions{Status: StatusDraft, Fields: []string{"name"}}
+
+	require.NoError(t, trans.SaveTranslationsWithOptions(ctx, []*versionedParameter{{ID: 1, Name: "Size"}}, draft))
+	repo.between = func() {
+		require.NoError(t, trans.SaveTranslationsWithOptions(ctx, []*versionedParameter{{ID: 1, Name: "Dimensions"}}, draft))
+	}
+
+	err := trans.TransitionTranslations(ctx, LocaleEN, []int{1}, []string{"name"}, StatusInReview)
+	require.ErrorIs(t, err, ErrVersionConflict)
+	stored, err := base.GetTranslations(ctx, LocaleEN, "versioned_parameter", []int{1})
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	require.Equal(t, "Dimensions", stored[0].Value, "the concurrent save is not undone")
+	require.Equal(t, StatusDraft, stored[0].Status)
+}